@@ -1,44 +1,129 @@
+// Package actions provides a router for htmx actions: handlers that return a Node,
+// registered together with the hx-* attribute that calls them.
 package actions
 
 import (
 	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
 	"strings"
+	"sync"
 
 	g "github.com/alarbada/gomponents"
-	"github.com/gin-gonic/gin"
+	ghttp "github.com/alarbada/gomponents/http"
 )
 
+// Mux is a router that actions are registered on. See ServeMux and Gin for the built-in adapters.
+// Path patterns use the http.ServeMux syntax, like "/todos/{id}" and "/files/{path...}".
+// Adapters for other routers, like chi or echo, must convert patterns to their own syntax,
+// and make path parameters available through http.Request.PathValue.
+type Mux interface {
+	http.Handler
+	Handle(method, pattern string, h http.Handler)
+}
+
+// Router registers actions on a Mux. Routers form a tree: the root is created with NewRouter or NewRouterWith,
+// and each group created with Group is a child, with its own path, name, and middleware.
 type Router struct {
-	groups []string
-	engine gin.IRouter
+	// ErrorRenderer renders the Node for errors returned by handlers and middleware without a Node,
+	// like an error message fragment. The status code is still set from the error, like ghttp.Adapt does.
+	// Only the ErrorRenderer of the root router is used.
+	ErrorRenderer func(r *http.Request, err error) g.Node
+
+	// ErrorLog logs errors while rendering responses, and handler errors without a status code.
+	// If nil, errors are logged with the log package's standard logger.
+	// Only the ErrorLog of the root router is used.
+	ErrorLog *log.Logger
+
+	parent     *Router
+	path       string
+	name       string
+	middleware []ghttp.Middleware
+	mux        Mux
+
+	// names, refs, and actions are only set on the root router, see names.go and routes.go.
+	// Refs can be created concurrently, so they're guarded by refsLock.
+	names       map[string]*Action
+	refsLock    sync.Mutex
+	refs        []Ref
+	refKeys     map[string]struct{}
+	refsChecked bool
+	actions     []*Action
+}
+
+// NewRouter backed by a new http.ServeMux.
+func NewRouter() *Router {
+	return NewRouterWith(ServeMux(http.NewServeMux()))
 }
 
-func NewRouter() Router {
-	router := gin.Default()
-	return Router{nil, router}
+// NewRouterWith creates a Router that registers actions on the given Mux.
+func NewRouterWith(mux Mux) *Router {
+	return &Router{mux: mux}
 }
 
-func (r *Router) Group(path string) Router {
-	group := r.engine.Group(path)
+// Group creates a child router for actions under the path, joined to the router path with a single slash.
+// "/api", "api", and "api/" all create the same group.
+// The middleware apply to the actions of the group and its child groups, after the middleware of the router.
+func (r *Router) Group(path string, middleware ...ghttp.Middleware) *Router {
+	return &Router{parent: r, path: path, middleware: slices.Clone(middleware), mux: r.mux}
+}
 
-	pathGroups := make([]string, len(r.groups))
-	copy(pathGroups, r.groups)
-	pathGroups = append(pathGroups, path)
+// Use adds middleware for the actions of the router and its child groups.
+// Middleware can short-circuit an action by returning a Node without calling the next Handler,
+// for example to respond with a login prompt fragment:
+//
+//	admin := r.Group("/admin")
+//	admin.Use(func(next ghttp.Handler) ghttp.Handler {
+//		return func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+//			if !loggedIn(r) {
+//				return LoginPrompt(), nil
+//			}
+//			return next(w, r)
+//		}
+//	})
+//
+// Middleware are looked up for every request, so they also apply to actions handled before Use is called.
+// Call Use while setting up the router, not while it's serving requests.
+// Errors returned by middleware are translated to HTTP responses like ghttp.Adapt does.
+func (r *Router) Use(middleware ...ghttp.Middleware) {
+	r.middleware = append(r.middleware, middleware...)
+}
 
-	return Router{pathGroups, group}
+// Name the router group. It returns the router, for chaining after Group.
+func (r *Router) Name(name string) *Router {
+	r.name = name
+	return r
 }
 
-func (r *Router) Engine() *gin.Engine {
-	if e, ok :=  r.engine.(*gin.Engine); ok {
-		return e
-	} else {
-		panic("cannot get static engine from non-engine router")
+// Path of the router, including the paths of its parent groups, without a trailing slash.
+func (r *Router) Path() string {
+	var paths []string
+	for ; r != nil; r = r.parent {
+		paths = append([]string{r.path}, paths...)
 	}
+	if p := joinPath(paths...); p != "/" {
+		return strings.TrimSuffix(p, "/")
+	}
+	return "/"
+}
+
+// Mux the actions are registered on.
+func (r *Router) Mux() Mux {
+	return r.mux
+}
+
+// ServeHTTP satisfies http.Handler.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mux.ServeHTTP(w, req)
 }
 
 type Action struct {
 	Method, Path string
 	router       *Router
+	name         string
+	handler      string
 }
 
 func (r *Router) action(method, path string) *Action {
@@ -55,38 +140,150 @@ func (r *Router) PUT(path string) *Action    { return r.action("PUT", path) }
 func (r *Router) DELETE(path string) *Action { return r.action("DELETE", path) }
 func (r *Router) PATCH(path string) *Action  { return r.action("PATCH", path) }
 
-func (a *Action) Handle(action func(c *gin.Context) g.Node) *Action {
-	wrapped := func(c *gin.Context) {
-		if result := action(c); result != nil {
-			result.Render(c.Writer)
-		}
-	}
+// Handle registers the action handler. The returned Node is rendered as HTML.
+func (a *Action) Handle(action func(w http.ResponseWriter, r *http.Request) g.Node) *Action {
+	return a.handle(action, func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+		return action(w, r), nil
+	})
+}
 
-	r := a.router.engine
-	method := a.Method
-	url := a.Path
-
-	switch method {
-	case "GET":
-		r.GET(url, wrapped)
-	case "POST":
-		r.POST(url, wrapped)
-	case "PUT":
-		r.PUT(url, wrapped)
-	case "DELETE":
-		r.DELETE(url, wrapped)
-	case "PATCH":
-		r.PATCH(url, wrapped)
+// HandleErr registers an action handler that can return an error.
+// Errors are translated to status codes like ghttp.Adapt does: if the error has a "StatusCode() int" method,
+// that status code is used, and otherwise 500 Internal Server Error.
+// The returned Node is rendered in both normal and error cases. If it's nil on error,
+// the Node of the root router's ErrorRenderer is rendered instead.
+func (a *Action) HandleErr(action ghttp.Handler) *Action {
+	return a.handle(action, action)
+}
+
+// handle registers the action. handler is the function given by the caller, which names the handler in Routes.
+func (a *Action) handle(handler any, h ghttp.Handler) *Action {
+	switch a.Method {
+	case "GET", "POST", "PUT", "DELETE", "PATCH":
 	default:
-		panic(fmt.Sprintf("invalid method %s", method))
+		panic(fmt.Sprintf("invalid method %s", a.Method))
 	}
 
+	root := a.router.root()
+	a.router.mux.Handle(a.Method, a.pattern(), ghttp.Adapt(root.serve(func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+		return a.chain(h)(w, r)
+	})))
+
+	a.handler = funcName(handler)
+	root.actions = append(root.actions, a)
+
 	return a
 }
 
+// chain wraps h with the middleware of the action's router and its parents,
+// from the innermost out, so the root router's first middleware runs first.
+func (a *Action) chain(h ghttp.Handler) ghttp.Handler {
+	for r := a.router; r != nil; r = r.parent {
+		for i := len(r.middleware) - 1; i >= 0; i-- {
+			h = r.middleware[i](h)
+		}
+	}
+	return h
+}
+
+// middlewareNames of the action's middleware, outermost first.
+func (a *Action) middlewareNames() []string {
+	var names []string
+	for r := a.router; r != nil; r = r.parent {
+		for i := len(r.middleware) - 1; i >= 0; i-- {
+			names = append([]string{funcName(r.middleware[i])}, names...)
+		}
+	}
+	return names
+}
+
+// serve the Handler with the default content type, the ErrorRenderer, and error logging.
+func (r *Router) serve(h ghttp.Handler) ghttp.Handler {
+	return func(w http.ResponseWriter, req *http.Request) (g.Node, error) {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		}
+
+		n, err := h(w, req)
+		if err != nil {
+			if _, ok := err.(interface{ StatusCode() int }); !ok {
+				r.logf("actions: %v %v: %v", req.Method, req.URL.Path, err)
+			}
+			if n == nil && r.ErrorRenderer != nil {
+				n = r.ErrorRenderer(req, err)
+			}
+		}
+		if n == nil {
+			return nil, err
+		}
+
+		return g.NodeFunc(func(w io.Writer) error {
+			if err := n.Render(w); err != nil {
+				r.logf("actions: rendering %v %v: %v", req.Method, req.URL.Path, err)
+				return err
+			}
+			return nil
+		}), err
+	}
+}
+
+// badRequestError wraps errors of requests that can't be parsed, and is translated to a 400 Bad Request.
+type badRequestError struct {
+	err error
+}
+
+func (e badRequestError) Error() string {
+	return e.err.Error()
+}
+
+func (e badRequestError) Unwrap() error {
+	return e.err
+}
+
+func (e badRequestError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+func (r *Router) logf(format string, args ...any) {
+	if r.ErrorLog != nil {
+		r.ErrorLog.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
 func (a *Action) Hx() g.Node {
-	groupPath := strings.Join(a.router.groups, "/")
-	path := groupPath + a.Path
+	return g.Attr("hx-"+strings.ToLower(a.Method), a.pattern())
+}
 
-	return g.Attr("hx-"+strings.ToLower(a.Method), path)
+// pattern of the action, including the group paths.
+func (a *Action) pattern() string {
+	if a.router == nil {
+		return joinPath(a.Path)
+	}
+	return joinPath(a.router.Path(), a.Path)
+}
+
+// joinPath joins the paths with single slashes, and adds a leading slash.
+// A trailing slash of the last non-empty path is kept, since http.ServeMux patterns ending in a slash match
+// all paths below them.
+func joinPath(paths ...string) string {
+	var b strings.Builder
+	trailing := false
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		for _, segment := range strings.Split(p, "/") {
+			if segment != "" {
+				b.WriteString("/")
+				b.WriteString(segment)
+			}
+		}
+		trailing = strings.HasSuffix(p, "/")
+	}
+	if trailing || b.Len() == 0 {
+		b.WriteString("/")
+	}
+	return b.String()
 }
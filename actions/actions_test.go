@@ -0,0 +1,313 @@
+package actions_test
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/actions"
+	ghttp "github.com/alarbada/gomponents/http"
+	"github.com/alarbada/gomponents/internal/assert"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRouter(t *testing.T) {
+	t.Run("renders the node returned by the action", func(t *testing.T) {
+		r := actions.NewRouter()
+		r.GET("/todos/{id}").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+			return g.El("li", g.Attr("id", r.PathValue("id")))
+		})
+
+		code, body := request(t, r, http.MethodGet, "/todos/1")
+		if code != http.StatusOK || body != `<li id="1"></li>` {
+			t.Fatal("response is", code, body)
+		}
+	})
+
+	t.Run("registers actions by method", func(t *testing.T) {
+		r := actions.NewRouter()
+		for _, a := range []*actions.Action{r.POST("/"), r.PUT("/"), r.DELETE("/"), r.PATCH("/")} {
+			method := a.Method
+			a.Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+				return g.El("p", g.Attr("title", method))
+			})
+		}
+
+		for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch} {
+			if _, body := request(t, r, method, "/"); body != `<p title="`+method+`"></p>` {
+				t.Fatal("body is", body)
+			}
+		}
+		if code, _ := request(t, r, http.MethodGet, "/"); code != http.StatusMethodNotAllowed {
+			t.Fatal("status code is", code)
+		}
+	})
+
+	t.Run("registers group actions under the group path", func(t *testing.T) {
+		r := actions.NewRouter()
+		a := r.Group("/api").GET("/todos").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+			return g.El("ul")
+		})
+
+		if _, body := request(t, r, http.MethodGet, "/api/todos"); body != "<ul></ul>" {
+			t.Fatal("body is", body)
+		}
+		assert.Equal(t, ` hx-get="/api/todos"`, a.Hx())
+	})
+
+	t.Run("joins nested group paths with single slashes", func(t *testing.T) {
+		r := actions.NewRouter()
+		cases := []struct {
+			action *actions.Action
+			url    string
+		}{
+			{r.GET("todos"), "/todos"},
+			{r.Group("/api/").GET("/todos"), "/api/todos"},
+			{r.Group("api").Group("v1").GET("todos"), "/api/v1/todos"},
+			{r.Group("/api/").Group("/v1/").Group("//admin").GET("/users/{id}"), "/api/v1/admin/users/{id}"},
+			{r.Group("/api").Group("").GET(""), "/api"},
+			{r.Group("/api/").GET(""), "/api"},
+			{r.Group("/api").GET("/files/"), "/api/files/"},
+		}
+
+		for _, c := range cases {
+			assert.Equal(t, ` hx-get="`+c.url+`"`, c.action.Hx())
+		}
+	})
+
+	t.Run("registers actions in deeply nested groups", func(t *testing.T) {
+		r := actions.NewRouter()
+		v1 := r.Group("/api/").Group("/v1/")
+		v1.Group("admin").GET("/users/{id}").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+			return g.El("p", g.Attr("id", r.PathValue("id")))
+		})
+
+		if _, body := request(t, r, http.MethodGet, "/api/v1/admin/users/1"); body != `<p id="1"></p>` {
+			t.Fatal("body is", body)
+		}
+		if v1.Path() != "/api/v1" {
+			t.Fatal("path is", v1.Path())
+		}
+	})
+
+	t.Run("panics on invalid methods", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("did not panic")
+			}
+		}()
+		(&actions.Action{Method: "HAT", Path: "/"}).Handle(nil)
+	})
+}
+
+type statusError int
+
+func (e statusError) Error() string   { return http.StatusText(int(e)) }
+func (e statusError) StatusCode() int { return int(e) }
+
+type erroringNode struct{}
+
+func (erroringNode) Render(w io.Writer) error {
+	_, _ = io.WriteString(w, "<p>")
+	return errors.New("render error")
+}
+
+func TestHandleErr(t *testing.T) {
+	t.Run("sets the HTML content type by default", func(t *testing.T) {
+		r := actions.NewRouter()
+		r.GET("/").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+			return g.El("p")
+		})
+		r.GET("/text").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+			w.Header().Set("Content-Type", "text/plain")
+			return nil
+		})
+
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		if ct := recorder.Header().Get("Content-Type"); ct != "text/html; charset=utf-8" {
+			t.Fatal("content type is", ct)
+		}
+
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/text", nil))
+		if ct := recorder.Header().Get("Content-Type"); ct != "text/plain" {
+			t.Fatal("content type is", ct)
+		}
+	})
+
+	t.Run("maps errors to status codes and renders the returned node", func(t *testing.T) {
+		r := actions.NewRouter()
+		r.ErrorLog = log.New(io.Discard, "", 0)
+		r.GET("/missing").HandleErr(func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+			return g.El("p", g.Attr("class", "missing")), statusError(http.StatusNotFound)
+		})
+		r.GET("/broken").HandleErr(func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+			return nil, errors.New("database is down")
+		})
+
+		if code, body := request(t, r, http.MethodGet, "/missing"); code != http.StatusNotFound || body != `<p class="missing"></p>` {
+			t.Fatal("response is", code, body)
+		}
+		if code, body := request(t, r, http.MethodGet, "/broken"); code != http.StatusInternalServerError || body != "" {
+			t.Fatal("response is", code, body)
+		}
+	})
+
+	t.Run("renders errors without a node with the error renderer of the root router", func(t *testing.T) {
+		r := actions.NewRouter()
+		r.ErrorLog = log.New(io.Discard, "", 0)
+		r.ErrorRenderer = func(r *http.Request, err error) g.Node {
+			return g.El("p", g.Attr("title", err.Error()))
+		}
+		r.Group("/api").GET("/").HandleErr(func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+			return nil, statusError(http.StatusForbidden)
+		})
+
+		if code, body := request(t, r, http.MethodGet, "/api/"); code != http.StatusForbidden || body != `<p title="Forbidden"></p>` {
+			t.Fatal("response is", code, body)
+		}
+	})
+
+	t.Run("logs render errors and errors without a status code", func(t *testing.T) {
+		var b strings.Builder
+		r := actions.NewRouter()
+		r.ErrorLog = log.New(&b, "", 0)
+		r.GET("/render").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+			return erroringNode{}
+		})
+		r.GET("/broken").HandleErr(func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+			return nil, errors.New("database is down")
+		})
+		r.GET("/missing").HandleErr(func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+			return nil, statusError(http.StatusNotFound)
+		})
+
+		request(t, r, http.MethodGet, "/render")
+		request(t, r, http.MethodGet, "/broken")
+		request(t, r, http.MethodGet, "/missing")
+		expected := "actions: rendering GET /render: render error\nactions: GET /broken: database is down\n"
+		if b.String() != expected {
+			t.Fatal("log is", b.String())
+		}
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Run("applies router and group middleware to actions beneath, outermost first", func(t *testing.T) {
+		r := actions.NewRouter()
+		r.Use(wrapIn("main"))
+		api := r.Group("/api", wrapIn("section"))
+		api.Use(wrapIn("article"))
+		api.Group("/v1").GET("/todos").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+			return g.El("ul")
+		})
+		r.GET("/").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+			return g.El("p")
+		})
+
+		if _, body := request(t, r, http.MethodGet, "/api/v1/todos"); body != "<main><section><article><ul></ul></article></section></main>" {
+			t.Fatal("body is", body)
+		}
+		if _, body := request(t, r, http.MethodGet, "/"); body != "<main><p></p></main>" {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("applies middleware added after the actions are handled", func(t *testing.T) {
+		r := actions.NewRouter()
+		admin := r.Group("/admin")
+		admin.GET("/secret").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+			return g.El("p", g.Attr("class", "secret"))
+		})
+		admin.Use(requireLogin)
+
+		code, body := request(t, r, http.MethodGet, "/admin/secret")
+		if code != http.StatusUnauthorized || body != `<p class="login"></p>` {
+			t.Fatal("response is", code, body)
+		}
+	})
+
+	t.Run("copies the group middleware", func(t *testing.T) {
+		middleware := make([]ghttp.Middleware, 1, 2)
+		middleware[0] = wrapIn("main")
+		r := actions.NewRouter()
+		a := r.Group("/a", middleware...)
+		b := r.Group("/b", middleware...)
+		a.Use(wrapIn("section"))
+		b.Use(wrapIn("article"))
+		a.GET("/").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+			return g.El("p")
+		})
+
+		if _, body := request(t, r, http.MethodGet, "/a/"); body != "<main><section><p></p></section></main>" {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("can short-circuit actions by returning a node", func(t *testing.T) {
+		r := actions.NewRouter()
+		r.Group("/admin", requireLogin).GET("/").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+			t.Fatal("action called")
+			return nil
+		})
+
+		code, body := request(t, r, http.MethodGet, "/admin/")
+		if code != http.StatusUnauthorized || body != `<p class="login"></p>` {
+			t.Fatal("response is", code, body)
+		}
+	})
+}
+
+func wrapIn(name string) ghttp.Middleware {
+	return func(next ghttp.Handler) ghttp.Handler {
+		return func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+			n, err := next(w, r)
+			return g.El(name, n), err
+		}
+	}
+}
+
+func requireLogin(next ghttp.Handler) ghttp.Handler {
+	return func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+		if r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return g.El("p", g.Attr("class", "login")), nil
+		}
+		return next(w, r)
+	}
+}
+
+func TestGin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("makes path parameters available as path values", func(t *testing.T) {
+		r := actions.NewRouterWith(actions.Gin(gin.New()))
+		r.GET("/todos/{id}/files/{path...}").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+			return g.El("a", g.Attr("id", r.PathValue("id")), g.Attr("href", r.PathValue("path")))
+		})
+
+		code, body := request(t, r, http.MethodGet, "/todos/1/files/a/b.txt")
+		if code != http.StatusOK || body != `<a id="1" href="a/b.txt"></a>` {
+			t.Fatal("response is", code, body)
+		}
+	})
+}
+
+func request(t *testing.T, h http.Handler, method, target string) (int, string) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader("")))
+	result := recorder.Result()
+	body, err := io.ReadAll(result.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result.StatusCode, string(body)
+}
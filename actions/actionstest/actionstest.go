@@ -0,0 +1,269 @@
+// Package actionstest provides a client for testing an actions.Router in-process, without a server.
+//
+// The Client keeps cookies between requests like a browser, and sends the CSRF token of the actions.CSRF
+// middleware with unsafe requests, like htmx does with actions.CSRFHeaders. Responses can be queried
+// with CSS selectors:
+//
+//	c := actionstest.New(t, r)
+//	res := c.Invoke("todo.create", nil, url.Values{"title": {"Buy hats"}}, actionstest.Hx("#todos"))
+//	if res.Status != http.StatusOK || len(res.Query("#todos li")) != 1 {
+//		t.Fatal(res.Body)
+//	}
+package actionstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/alarbada/gomponents/actions"
+	"github.com/alarbada/gomponents/hx"
+)
+
+// baseURL of requests, for the cookie jar.
+const baseURL = "http://example.com"
+
+// Client makes requests to a Router in-process. Create it with New.
+type Client struct {
+	// Header is sent with every request, for example for authentication.
+	Header http.Header
+
+	t      testing.TB
+	router *actions.Router
+	jar    *cookiejar.Jar
+}
+
+// New Client for the router. Errors fail the test t.
+func New(t testing.TB, r *actions.Router) *Client {
+	t.Helper()
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Client{Header: http.Header{}, t: t, router: r, jar: jar}
+}
+
+// Hx returns headers of an htmx request, with "HX-Request: true" and the id of the target element
+// in HX-Target, if target is not empty. A leading "#" of target is removed.
+func Hx(target string) http.Header {
+	h := http.Header{"Hx-Request": {"true"}}
+	if target != "" {
+		h.Set("HX-Target", strings.TrimPrefix(target, "#"))
+	}
+	return h
+}
+
+// Response of a request.
+type Response struct {
+	Status int
+	Header http.Header
+	// Body is the rendered HTML, or whatever else the action wrote.
+	Body string
+
+	client *Client
+	doc    *html.Node
+}
+
+// Do a request with the method to the path, which can include a query.
+// The form values are encoded in the query for GET and DELETE requests, and in the body for other methods.
+// header is added to the Client.Header, and can be nil.
+func (c *Client) Do(method, path string, form url.Values, header http.Header) *Response {
+	c.t.Helper()
+
+	var body io.Reader
+	if len(form) > 0 {
+		if method == http.MethodGet || method == http.MethodDelete {
+			if strings.Contains(path, "?") {
+				path += "&" + form.Encode()
+			} else {
+				path += "?" + form.Encode()
+			}
+		} else {
+			body = strings.NewReader(form.Encode())
+		}
+	}
+
+	req := httptest.NewRequest(method, baseURL+path, body)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	for _, h := range []http.Header{c.Header, header} {
+		for k, v := range h {
+			req.Header[k] = v
+		}
+	}
+	for _, cookie := range c.jar.Cookies(req.URL) {
+		req.AddCookie(cookie)
+		if cookie.Name == actions.CSRFCookieName && req.Header.Get(actions.CSRFHeaderName) == "" &&
+			method != http.MethodGet && method != http.MethodHead {
+			req.Header.Set(actions.CSRFHeaderName, cookie.Value)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	c.router.ServeHTTP(recorder, req)
+	result := recorder.Result()
+	c.jar.SetCookies(req.URL, result.Cookies())
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return &Response{Status: result.StatusCode, Header: result.Header, Body: string(b), client: c}
+}
+
+// Invoke the action with the name, as given to actions.Action.Name, with its method and its URL for params.
+// See Do for form and header.
+func (c *Client) Invoke(name string, params actions.Params, form url.Values, header http.Header) *Response {
+	c.t.Helper()
+
+	for _, route := range c.router.Routes() {
+		if route.Name == name {
+			return c.Do(route.Method, c.router.URL(name, params), form, header)
+		}
+	}
+	c.t.Fatalf("no handled action named %v", name)
+	return nil
+}
+
+// Get the path. See Do.
+func (c *Client) Get(path string, header http.Header) *Response {
+	c.t.Helper()
+	return c.Do(http.MethodGet, path, nil, header)
+}
+
+// Post the form to the path. See Do.
+func (c *Client) Post(path string, form url.Values, header http.Header) *Response {
+	c.t.Helper()
+	return c.Do(http.MethodPost, path, form, header)
+}
+
+// Follow the HX-Redirect or HX-Location response header, like htmx does.
+// HX-Redirect is followed with a normal GET request, and HX-Location with an htmx GET request,
+// with the target, headers, and values of the location. It fails the test if there's neither header.
+func (r *Response) Follow() *Response {
+	c := r.client
+	c.t.Helper()
+
+	if redirect := r.Header.Get("HX-Redirect"); redirect != "" {
+		return c.Get(redirect, nil)
+	}
+
+	location := r.Header.Get("HX-Location")
+	if location == "" {
+		c.t.Fatalf("response with status %v has no HX-Redirect or HX-Location header", r.Status)
+	}
+
+	var l hx.Location
+	if strings.HasPrefix(location, "{") {
+		if err := json.Unmarshal([]byte(location), &l); err != nil {
+			c.t.Fatalf("invalid HX-Location header %v: %v", location, err)
+		}
+	} else {
+		l.Path = location
+	}
+
+	header := Hx(l.Target)
+	for k, v := range l.Headers {
+		header.Set(k, v)
+	}
+	form := url.Values{}
+	for k, v := range l.Values {
+		form.Set(k, fmt.Sprint(v))
+	}
+	return c.Do(http.MethodGet, l.Path, form, header)
+}
+
+// Query the response body for the elements matching the CSS selector.
+// Supported are type, universal ("*"), id, class, and attribute selectors ("[name]" and "[name=value]"),
+// the descendant (" ") and child (">") combinators, and selector lists separated by commas.
+// It fails the test if the selector is invalid.
+func (r *Response) Query(selector string) []*html.Node {
+	c := r.client
+	c.t.Helper()
+
+	sel, err := parseSelector(selector)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	if r.doc == nil {
+		r.doc = parse(c.t, r.Body)
+	}
+
+	var nodes []*html.Node
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && sel.matches(n) {
+			nodes = append(nodes, n)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(r.doc)
+	return nodes
+}
+
+// parse the body as a document if it has a doctype or html element, and as a fragment of body otherwise.
+func parse(t testing.TB, body string) *html.Node {
+	t.Helper()
+
+	prefix := strings.ToLower(strings.TrimSpace(body))
+	if strings.HasPrefix(prefix, "<!doctype") || strings.HasPrefix(prefix, "<html") {
+		doc, err := html.Parse(strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return doc
+	}
+
+	nodes, err := html.ParseFragment(strings.NewReader(body), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := &html.Node{Type: html.DocumentNode}
+	for _, n := range nodes {
+		doc.AppendChild(n)
+	}
+	return doc
+}
+
+// Text content of the node and its descendants.
+func Text(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(Text(child))
+	}
+	return b.String()
+}
+
+// Attr returns the value of the attribute with the name on the node, and true, or false if it has none.
+func Attr(n *html.Node, name string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Namespace == "" && a.Key == name {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+// HTML of the node, as rendered again after parsing.
+func HTML(n *html.Node) string {
+	var b strings.Builder
+	_ = html.Render(&b, n)
+	return b.String()
+}
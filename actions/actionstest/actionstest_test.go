@@ -0,0 +1,140 @@
+package actionstest_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/actions"
+	"github.com/alarbada/gomponents/actions/actionstest"
+	h "github.com/alarbada/gomponents/html"
+	"github.com/alarbada/gomponents/hx"
+)
+
+func newRouter() *actions.Router {
+	r := actions.NewRouter()
+	r.Use(actions.CSRF([]byte("0123456789abcdef0123456789abcdef"), nil))
+
+	todos := []string{"Buy hats"}
+	list := func() g.Node {
+		var items []g.Node
+		for _, todo := range todos {
+			items = append(items, h.Li(h.Class("todo"), h.Text(todo)))
+		}
+		return h.Ul(h.ID("todos"), g.Group(items))
+	}
+
+	r.GET("/").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+		if hx.Request(r).Request {
+			return h.P(h.Class("partial"), h.Text(hx.Request(r).Target))
+		}
+		return h.Doctype(h.HTML(h.Body(actions.CSRFHeaders(r), h.Main(list()))))
+	}).Name("home")
+	r.GET("/todos/{id}").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+		return h.Article(h.DataAttr("id", r.PathValue("id")), h.Text(r.URL.Query().Get("view")))
+	}).Name("todo.show")
+	r.POST("/todos").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+		todos = append(todos, r.FormValue("title"))
+		return list()
+	}).Name("todo.create")
+	r.POST("/logout").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+		hx.SetRedirect(w, "/")
+		return nil
+	})
+	r.POST("/archive").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+		_ = hx.SetLocation(w, hx.Location{Path: "/todos/1", Target: "#main", Values: map[string]any{"view": "compact"}})
+		return nil
+	})
+	r.POST("/back").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+		_ = hx.SetLocation(w, hx.Location{Path: "/"})
+		return nil
+	})
+	return r
+}
+
+func TestClient(t *testing.T) {
+	t.Run("gets pages and queries them by selector", func(t *testing.T) {
+		c := actionstest.New(t, newRouter())
+		res := c.Get("/", nil)
+		if res.Status != http.StatusOK || res.Header.Get("Content-Type") != "text/html; charset=utf-8" {
+			t.Fatal("response is", res.Status, res.Header)
+		}
+
+		items := res.Query("main > ul#todos li.todo")
+		if len(items) != 1 || actionstest.Text(items[0]) != "Buy hats" {
+			t.Fatal("body is", res.Body)
+		}
+		if len(res.Query("body[hx-headers]")) != 1 || len(res.Query("section, li")) != 1 {
+			t.Fatal("body is", res.Body)
+		}
+	})
+
+	t.Run("invokes actions by name with the CSRF token and htmx headers", func(t *testing.T) {
+		c := actionstest.New(t, newRouter())
+		if res := c.Invoke("todo.create", nil, url.Values{"title": {"Buy more hats"}}, nil); res.Status != http.StatusForbidden {
+			t.Fatal("status is", res.Status)
+		}
+
+		c.Get("/", nil)
+		res := c.Invoke("todo.create", nil, url.Values{"title": {"Buy more hats"}}, actionstest.Hx("#todos"))
+		items := res.Query("#todos > li")
+		if res.Status != http.StatusOK || len(items) != 2 || actionstest.Text(items[1]) != "Buy more hats" {
+			t.Fatal("response is", res.Status, res.Body)
+		}
+		assertEqual(t, `<li class="todo">Buy more hats</li>`, actionstest.HTML(items[1]))
+
+		res = c.Invoke("todo.show", actions.Params{"id": "1"}, url.Values{"view": {"full"}}, nil)
+		if article := res.Query(`article[data-id="1"]`); len(article) != 1 || actionstest.Text(article[0]) != "full" {
+			t.Fatal("body is", res.Body)
+		}
+	})
+
+	t.Run("sends the client header with every request", func(t *testing.T) {
+		c := actionstest.New(t, newRouter())
+		c.Header = actionstest.Hx("main")
+		res := c.Get("/", nil)
+		if p := res.Query("p.partial"); len(p) != 1 || actionstest.Text(p[0]) != "main" {
+			t.Fatal("body is", res.Body)
+		}
+	})
+
+	t.Run("follows HX-Redirect and HX-Location", func(t *testing.T) {
+		c := actionstest.New(t, newRouter())
+		c.Get("/", nil)
+
+		if res := c.Post("/logout", nil, nil).Follow(); len(res.Query("#todos")) != 1 {
+			t.Fatal("body is", res.Body)
+		}
+
+		res := c.Post("/archive", nil, nil).Follow()
+		if article := res.Query("article"); len(article) != 1 || actionstest.Text(article[0]) != "compact" {
+			t.Fatal("body is", res.Body)
+		}
+
+		res = c.Post("/back", nil, nil).Follow()
+		if p := res.Query("p.partial"); len(p) != 1 || actionstest.Text(p[0]) != "" {
+			t.Fatal("body is", res.Body)
+		}
+	})
+}
+
+func TestAttr(t *testing.T) {
+	t.Run("returns attribute values", func(t *testing.T) {
+		c := actionstest.New(t, newRouter())
+		ul := c.Get("/", nil).Query("ul")[0]
+		if id, ok := actionstest.Attr(ul, "id"); !ok || id != "todos" {
+			t.Fatal("id is", id)
+		}
+		if _, ok := actionstest.Attr(ul, "class"); ok {
+			t.Fatal("has class")
+		}
+	})
+}
+
+func assertEqual(t *testing.T, expected, actual string) {
+	t.Helper()
+	if expected != actual {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+}
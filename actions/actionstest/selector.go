@@ -0,0 +1,274 @@
+package actionstest
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// selector is a parsed CSS selector: a list of complex selectors separated by commas.
+type selector []complexSelector
+
+// complexSelector is a sequence of compound selectors with combinators between them.
+// combinators[i] is the combinator before compounds[i+1], either ' ' for descendants or '>' for children.
+type complexSelector struct {
+	compounds   []compoundSelector
+	combinators []byte
+}
+
+// compoundSelector matches a single element, like "li.done#first[data-id=1]".
+type compoundSelector struct {
+	tag     string
+	id      string
+	classes []string
+	attrs   []attrSelector
+}
+
+// attrSelector matches an attribute, by presence, or with hasValue also by exact value.
+type attrSelector struct {
+	name, value string
+	hasValue    bool
+}
+
+// parseSelector parses the subset of CSS selectors supported by Response.Query:
+// type, universal, id, class, and attribute selectors (presence or exact value),
+// combined with the descendant and child combinators, and grouped with commas.
+func parseSelector(s string) (selector, error) {
+	var sel selector
+	for _, group := range splitList(s) {
+		c, err := parseComplex(group)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector %q: %w", s, err)
+		}
+		sel = append(sel, c)
+	}
+	return sel, nil
+}
+
+// splitList splits a selector list at the commas outside of quotes and attribute selectors.
+func splitList(s string) []string {
+	var groups []string
+	var quote byte
+	brackets, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch b := s[i]; {
+		case quote != 0:
+			if b == quote {
+				quote = 0
+			}
+		case b == '"' || b == '\'':
+			quote = b
+		case b == '[':
+			brackets++
+		case b == ']':
+			brackets--
+		case b == ',' && brackets == 0:
+			groups = append(groups, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(groups, s[start:])
+}
+
+func parseComplex(s string) (complexSelector, error) {
+	var c complexSelector
+	p := &selectorParser{s: strings.TrimSpace(s)}
+	if p.s == "" {
+		return c, fmt.Errorf("empty selector")
+	}
+
+	for {
+		compound, err := p.compound()
+		if err != nil {
+			return c, err
+		}
+		c.compounds = append(c.compounds, compound)
+
+		spaces := p.skipSpaces()
+		if p.done() {
+			return c, nil
+		}
+		switch {
+		case p.peek() == '>':
+			p.i++
+			p.skipSpaces()
+			c.combinators = append(c.combinators, '>')
+		case spaces:
+			c.combinators = append(c.combinators, ' ')
+		default:
+			return c, fmt.Errorf("unexpected %q", p.peek())
+		}
+	}
+}
+
+type selectorParser struct {
+	s string
+	i int
+}
+
+func (p *selectorParser) done() bool { return p.i >= len(p.s) }
+func (p *selectorParser) peek() byte { return p.s[p.i] }
+
+func (p *selectorParser) skipSpaces() bool {
+	start := p.i
+	for !p.done() && p.peek() == ' ' {
+		p.i++
+	}
+	return p.i > start
+}
+
+// name reads an identifier, like a tag, class, or attribute name.
+func (p *selectorParser) name() string {
+	start := p.i
+	for !p.done() {
+		b := p.peek()
+		if !(b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '-' || b == '_' || b == ':') {
+			break
+		}
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+func (p *selectorParser) compound() (compoundSelector, error) {
+	var c compoundSelector
+	start := p.i
+	if !p.done() && p.peek() == '*' {
+		p.i++
+	} else {
+		c.tag = strings.ToLower(p.name())
+	}
+
+	for !p.done() {
+		switch p.peek() {
+		case '#':
+			p.i++
+			if c.id = p.name(); c.id == "" {
+				return c, fmt.Errorf("missing id after #")
+			}
+		case '.':
+			p.i++
+			class := p.name()
+			if class == "" {
+				return c, fmt.Errorf("missing class after .")
+			}
+			c.classes = append(c.classes, class)
+		case '[':
+			p.i++
+			a, err := p.attr()
+			if err != nil {
+				return c, err
+			}
+			c.attrs = append(c.attrs, a)
+		default:
+			if p.i == start {
+				return c, fmt.Errorf("unexpected %q", p.peek())
+			}
+			return c, nil
+		}
+	}
+	if p.i == start {
+		return c, fmt.Errorf("missing selector")
+	}
+	return c, nil
+}
+
+// attr parses an attribute selector after the opening bracket.
+func (p *selectorParser) attr() (attrSelector, error) {
+	var a attrSelector
+	p.skipSpaces()
+	if a.name = strings.ToLower(p.name()); a.name == "" {
+		return a, fmt.Errorf("missing attribute name")
+	}
+	p.skipSpaces()
+	if p.done() {
+		return a, fmt.Errorf("missing ]")
+	}
+
+	if p.peek() == '=' {
+		p.i++
+		p.skipSpaces()
+		a.hasValue = true
+		if !p.done() && (p.peek() == '"' || p.peek() == '\'') {
+			quote := p.peek()
+			end := strings.IndexByte(p.s[p.i+1:], quote)
+			if end < 0 {
+				return a, fmt.Errorf("missing closing quote")
+			}
+			a.value = p.s[p.i+1 : p.i+1+end]
+			p.i += end + 2
+		} else {
+			a.value = p.name()
+		}
+		p.skipSpaces()
+	}
+
+	if p.done() || p.peek() != ']' {
+		return a, fmt.Errorf("missing ]")
+	}
+	p.i++
+	return a, nil
+}
+
+// matches reports whether the element node n matches the selector.
+func (s selector) matches(n *html.Node) bool {
+	for _, c := range s {
+		if c.matches(n, len(c.compounds)-1) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether n matches the compounds up to and including index i.
+func (c complexSelector) matches(n *html.Node, i int) bool {
+	if !c.compounds[i].matches(n) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+
+	if c.combinators[i-1] == '>' {
+		return isElement(n.Parent) && c.matches(n.Parent, i-1)
+	}
+	for a := n.Parent; isElement(a); a = a.Parent {
+		if c.matches(a, i-1) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c compoundSelector) matches(n *html.Node) bool {
+	if c.tag != "" && n.Data != c.tag {
+		return false
+	}
+	if c.id != "" {
+		if id, ok := Attr(n, "id"); !ok || id != c.id {
+			return false
+		}
+	}
+	for _, class := range c.classes {
+		v, _ := Attr(n, "class")
+		found := false
+		for _, f := range strings.Fields(v) {
+			found = found || f == class
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, a := range c.attrs {
+		v, ok := Attr(n, a.name)
+		if !ok || a.hasValue && v != a.value {
+			return false
+		}
+	}
+	return true
+}
+
+func isElement(n *html.Node) bool {
+	return n != nil && n.Type == html.ElementNode
+}
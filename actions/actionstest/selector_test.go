@@ -0,0 +1,76 @@
+package actionstest
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestSelector(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<main id="main"><ul class="todos big"><li data-id="1" class="done">a</li>` +
+		`<li data-id="2" data-x="a,b"><span class="done">b</span></li></ul><p class="done">c</p></main>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]string{
+		"li":                      "a b",
+		"*.done":                  "a b c",
+		"#main .done":             "a b c",
+		"ul > .done":              "a",
+		"main > .done":            "c",
+		"main .done":              "a b c",
+		"ul.todos.big li span":    "b",
+		"li[data-id]":             "a b",
+		`li[data-id="2"]`:         "b",
+		"li[data-id='1']":         "a",
+		"li[ data-id = 1 ]":       "a",
+		"p, span":                 "b c",
+		`li[data-x="a,b"]`:        "b",
+		`li[data-x='a,b'], p`:     "b c",
+		"ul.small li":             "",
+		"main  >  ul  >  li.done": "a",
+	}
+
+	for selector, expected := range cases {
+		t.Run(selector, func(t *testing.T) {
+			sel, err := parseSelector(selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var texts []string
+			var walk func(n *html.Node)
+			walk = func(n *html.Node) {
+				if n.Type == html.ElementNode && sel.matches(n) {
+					var b strings.Builder
+					for c := n.FirstChild; c != nil; c = c.NextSibling {
+						if c.Type == html.TextNode {
+							b.WriteString(c.Data)
+						} else if c.FirstChild != nil && c.FirstChild.Type == html.TextNode {
+							b.WriteString(c.FirstChild.Data)
+						}
+					}
+					texts = append(texts, b.String())
+				}
+				for c := n.FirstChild; c != nil; c = c.NextSibling {
+					walk(c)
+				}
+			}
+			walk(doc)
+
+			if actual := strings.Join(texts, " "); actual != expected {
+				t.Fatalf("expected %q but got %q", expected, actual)
+			}
+		})
+	}
+
+	t.Run("errors on invalid selectors", func(t *testing.T) {
+		for _, selector := range []string{"", "li,", "ul >", "> li", "li.", "#", "li[", "li[id", `li[id="1]`, "li!", "li + p"} {
+			if _, err := parseSelector(selector); err == nil {
+				t.Fatalf("no error for %q", selector)
+			}
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package actions
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"slices"
+	"strings"
+
+	g "github.com/alarbada/gomponents"
+	h "github.com/alarbada/gomponents/html"
+	ghttp "github.com/alarbada/gomponents/http"
+	"github.com/alarbada/gomponents/hx"
+)
+
+const (
+	// CSRFCookieName is the name of the cookie with the CSRF token.
+	CSRFCookieName = "_csrf"
+	// CSRFFieldName is the name of the form field that CSRFInput renders.
+	CSRFFieldName = "_csrf"
+	// CSRFHeaderName is the name of the request header that CSRFHeaders makes htmx send.
+	CSRFHeaderName = "X-CSRF-Token"
+)
+
+type csrfContextKey struct{}
+
+// CSRF returns a Middleware that protects actions against cross-site request forgery with a signed
+// double-submit cookie. Each browser session gets a random token in a cookie, signed with HMAC-SHA256 and the key.
+// Requests with unsafe methods, like POST, PUT, PATCH, and DELETE, must send the same token in the X-CSRF-Token
+// header or the _csrf form field, and the token must have a valid signature. Because of the signature,
+// an attacker who can set cookies for the domain, for example from a sibling subdomain, can't choose a token.
+// The key must be secret, at least 32 bytes long, and the same for all instances of the application.
+// Use CSRFHeaders on an element to make htmx send the header for all requests below it,
+// and CSRFInput in forms that are submitted without htmx.
+//
+// Requests with a missing or wrong token get a 403 Forbidden response with the Node returned by failure,
+// which can be nil to render a short message. Use it on the root router to protect all actions:
+//
+//	r := actions.NewRouter()
+//	r.Use(actions.CSRF(key, nil))
+//
+// It panics if the key is shorter than 32 bytes.
+func CSRF(key []byte, failure func(r *http.Request) g.Node) ghttp.Middleware {
+	if len(key) < 32 {
+		panic("CSRF key must be at least 32 bytes long")
+	}
+	key = slices.Clone(key)
+
+	if failure == nil {
+		failure = func(*http.Request) g.Node {
+			return h.P(h.Text("Invalid or missing CSRF token. Please reload the page and try again."))
+		}
+	}
+
+	return func(next ghttp.Handler) ghttp.Handler {
+		return func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+			token := ""
+			if c, err := r.Cookie(CSRFCookieName); err == nil && validCSRFToken(key, c.Value) {
+				token = c.Value
+			}
+
+			if !isSafeMethod(r.Method) {
+				sent := r.Header.Get(CSRFHeaderName)
+				if sent == "" {
+					sent = r.PostFormValue(CSRFFieldName)
+				}
+				if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(sent)) != 1 {
+					w.WriteHeader(http.StatusForbidden)
+					return failure(r), nil
+				}
+			}
+
+			if token == "" {
+				var err error
+				if token, err = newCSRFToken(key); err != nil {
+					return nil, err
+				}
+				http.SetCookie(w, &http.Cookie{
+					Name:     CSRFCookieName,
+					Value:    token,
+					Path:     "/",
+					HttpOnly: true,
+					Secure:   r.TLS != nil,
+					SameSite: http.SameSiteLaxMode,
+				})
+			}
+
+			return next(w, r.WithContext(context.WithValue(r.Context(), csrfContextKey{}, token)))
+		}
+	}
+}
+
+// CSRFToken of the request, as set by the CSRF middleware. It's empty if the middleware isn't used.
+func CSRFToken(r *http.Request) string {
+	token, _ := r.Context().Value(csrfContextKey{}).(string)
+	return token
+}
+
+// CSRFInput returns a hidden input with the CSRF token of the request, for forms submitted without htmx.
+func CSRFInput(r *http.Request) g.Node {
+	return h.Input(h.Type("hidden"), h.Name(CSRFFieldName), h.Value(CSRFToken(r)))
+}
+
+// CSRFHeaders returns an hx-headers attribute with the CSRF token of the request.
+// htmx inherits it, so putting it on the body element covers all htmx requests of the page.
+func CSRFHeaders(r *http.Request) g.Node {
+	return hx.HeadersOf(map[string]string{CSRFHeaderName: CSRFToken(r)})
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// newCSRFToken of a random nonce and its signature.
+func newCSRFToken(key []byte) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	nonce := base64.RawURLEncoding.EncodeToString(b)
+	return nonce + "." + signCSRFNonce(key, nonce), nil
+}
+
+// validCSRFToken reports whether the token has a valid signature of its nonce.
+func validCSRFToken(key []byte, token string) bool {
+	nonce, signature, ok := strings.Cut(token, ".")
+	if !ok || nonce == "" {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(signCSRFNonce(key, nonce)))
+}
+
+func signCSRFNonce(key []byte, nonce string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(nonce))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,163 @@
+package actions_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/actions"
+	"github.com/alarbada/gomponents/internal/assert"
+)
+
+var csrfKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestCSRF(t *testing.T) {
+	r := actions.NewRouter()
+	r.Use(actions.CSRF(csrfKey, nil))
+	r.GET("/").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+		return g.El("form", actions.CSRFInput(r))
+	})
+	r.POST("/todos").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+		return g.El("li")
+	})
+
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	cookies := recorder.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != "_csrf" || !cookies[0].HttpOnly || len(cookies[0].Value) != 87 {
+		t.Fatalf("cookies are %v", cookies)
+	}
+	cookie := cookies[0]
+
+	t.Run("issues a token and renders it in a hidden input", func(t *testing.T) {
+		expected := `<form><input type="hidden" name="_csrf" value="` + cookie.Value + `"></form>`
+		if body := recorder.Body.String(); body != expected {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("keeps the token of the session", func(t *testing.T) {
+		code, header, _ := csrfRequest(t, r, http.MethodGet, "/", cookie, nil, "")
+		if code != http.StatusOK || header.Get("Set-Cookie") != "" {
+			t.Fatal("response is", code, header)
+		}
+	})
+
+	t.Run("accepts unsafe methods with the token in the header or form", func(t *testing.T) {
+		header := http.Header{"X-Csrf-Token": {cookie.Value}}
+		if code, _, body := csrfRequest(t, r, http.MethodPost, "/todos", cookie, header, ""); code != http.StatusOK || body != "<li></li>" {
+			t.Fatal("response is", code, body)
+		}
+
+		form := url.Values{"_csrf": {cookie.Value}}.Encode()
+		if code, _, body := csrfRequest(t, r, http.MethodPost, "/todos", cookie, nil, form); code != http.StatusOK || body != "<li></li>" {
+			t.Fatal("response is", code, body)
+		}
+	})
+
+	t.Run("rejects unsafe methods with a missing or wrong token", func(t *testing.T) {
+		cases := map[string]struct {
+			cookie *http.Cookie
+			header http.Header
+		}{
+			"no token":     {cookie, nil},
+			"wrong token":  {cookie, http.Header{"X-Csrf-Token": {"hat"}}},
+			"no cookie":    {nil, http.Header{"X-Csrf-Token": {cookie.Value}}},
+			"empty tokens": {&http.Cookie{Name: "_csrf"}, http.Header{"X-Csrf-Token": {""}}},
+		}
+		for name, c := range cases {
+			t.Run(name, func(t *testing.T) {
+				code, _, body := csrfRequest(t, r, http.MethodPost, "/todos", c.cookie, c.header, "")
+				if code != http.StatusForbidden || !strings.HasPrefix(body, "<p>Invalid or missing CSRF token.") {
+					t.Fatal("response is", code, body)
+				}
+			})
+		}
+	})
+
+	t.Run("rejects tokens chosen by an attacker", func(t *testing.T) {
+		other := actions.NewRouter()
+		other.Use(actions.CSRF([]byte("fedcba9876543210fedcba9876543210"), nil))
+		other.GET("/").Handle(func(w http.ResponseWriter, r *http.Request) g.Node { return nil })
+		recorder := httptest.NewRecorder()
+		other.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		signedWithOtherKey := recorder.Result().Cookies()[0].Value
+
+		for _, token := range []string{"hat", "hat.hat", signedWithOtherKey, cookie.Value + "x"} {
+			chosen := &http.Cookie{Name: "_csrf", Value: token}
+			header := http.Header{"X-Csrf-Token": {token}}
+			if code, _, _ := csrfRequest(t, r, http.MethodPost, "/todos", chosen, header, ""); code != http.StatusForbidden {
+				t.Fatal("status code is", code, "for", token)
+			}
+		}
+	})
+
+	t.Run("replaces invalid cookies", func(t *testing.T) {
+		_, header, _ := csrfRequest(t, r, http.MethodGet, "/", &http.Cookie{Name: "_csrf", Value: "hat"}, nil, "")
+		if !strings.HasPrefix(header.Get("Set-Cookie"), "_csrf=") {
+			t.Fatal("header is", header)
+		}
+	})
+
+	t.Run("panics on short keys", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("did not panic")
+			}
+		}()
+		actions.CSRF([]byte("hat"), nil)
+	})
+
+	t.Run("renders the failure node", func(t *testing.T) {
+		r := actions.NewRouter()
+		r.Use(actions.CSRF(csrfKey, func(r *http.Request) g.Node { return g.El("dialog") }))
+		r.DELETE("/todos/1").Handle(func(w http.ResponseWriter, r *http.Request) g.Node { return nil })
+
+		if code, _, body := csrfRequest(t, r, http.MethodDelete, "/todos/1", nil, nil, ""); code != http.StatusForbidden || body != "<dialog></dialog>" {
+			t.Fatal("response is", code, body)
+		}
+	})
+}
+
+func TestCSRFHeaders(t *testing.T) {
+	t.Run("returns an hx-headers attribute with the token", func(t *testing.T) {
+		r := actions.NewRouter()
+		r.Use(actions.CSRF(csrfKey, nil))
+		var token string
+		var n g.Node
+		r.GET("/").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+			token, n = actions.CSRFToken(r), actions.CSRFHeaders(r)
+			return nil
+		})
+		request(t, r, http.MethodGet, "/")
+
+		assert.Equal(t, ` hx-headers="{&#34;X-CSRF-Token&#34;:&#34;`+token+`&#34;}"`, n)
+	})
+}
+
+func csrfRequest(t *testing.T, h http.Handler, method, target string, cookie *http.Cookie, header http.Header, form string) (int, http.Header, string) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, target, strings.NewReader(form))
+	for k, v := range header {
+		request.Header[k] = v
+	}
+	if form != "" {
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if cookie != nil {
+		request.AddCookie(cookie)
+	}
+	h.ServeHTTP(recorder, request)
+	result := recorder.Result()
+	body, err := io.ReadAll(result.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result.StatusCode, result.Header, string(body)
+}
@@ -0,0 +1,112 @@
+package actions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	g "github.com/alarbada/gomponents"
+)
+
+// ValidationErrors maps form field names to error messages.
+type ValidationErrors map[string]string
+
+// Error satisfies error.
+func (e ValidationErrors) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field + ": " + e[field]
+	}
+	return strings.Join(messages, ", ")
+}
+
+// Validator is implemented by form types that validate themselves after binding.
+// Validate returns nil or empty ValidationErrors if the form is valid.
+type Validator interface {
+	Validate() ValidationErrors
+}
+
+// HandleForm registers a handler on the action, that gets the request body bound to the struct type T.
+// JSON bodies are decoded with encoding/json. Other bodies are parsed as forms, and fields of T with a "form"
+// tag are filled from the form value with that name, like the "query" fields of a TypedAction.
+// If T or *T implements Validator, it's validated after binding.
+// If a value can't be bound or the form is invalid, handle isn't called. Instead, the response is a
+// 422 Unprocessable Entity with the Node returned by render, which usually renders the form again with the errors.
+// A body that can't be parsed at all gets a 400 Bad Request, with the Node of the router's ErrorRenderer.
+// It panics if T is not a struct with supported form field types.
+func HandleForm[T any](a *Action, handle func(w http.ResponseWriter, r *http.Request, form T) g.Node,
+	render func(form T, errs ValidationErrors) g.Node) *Action {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("form type %v must be a struct", typ))
+	}
+	fields := tagFields(typ, "form", true)
+
+	return a.handle(handle, func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+		var form T
+		errs, err := bind(r, reflect.ValueOf(&form).Elem(), fields)
+		if err != nil {
+			return nil, badRequestError{err}
+		}
+
+		if v, ok := any(&form).(Validator); ok && len(errs) == 0 {
+			errs = v.Validate()
+		}
+		if len(errs) > 0 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return render(form, errs), nil
+		}
+
+		return handle(w, r, form), nil
+	})
+}
+
+// bind the request body to v. Values that can't be bound are returned as ValidationErrors,
+// and a body that can't be parsed as an error.
+func bind(r *http.Request, v reflect.Value, fields []paramField) (ValidationErrors, error) {
+	errs := ValidationErrors{}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		err := json.NewDecoder(r.Body).Decode(v.Addr().Interface())
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			errs[typeErr.Field] = "invalid value"
+			return errs, nil
+		}
+		return errs, err
+
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return nil, err
+		}
+
+	default:
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range fields {
+		values, ok := r.Form[f.name]
+		if !ok || len(values) == 0 {
+			continue
+		}
+		if err := setField(v.FieldByIndex(f.index), values); err != nil {
+			errs[f.name] = "invalid value"
+		}
+	}
+	return errs, nil
+}
@@ -0,0 +1,126 @@
+package actions_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/actions"
+)
+
+type todoForm struct {
+	Title    string `form:"title" json:"title"`
+	Priority int    `form:"priority" json:"priority"`
+}
+
+func (f todoForm) Validate() actions.ValidationErrors {
+	if f.Title == "" {
+		return actions.ValidationErrors{"title": "is required"}
+	}
+	return nil
+}
+
+func TestHandleForm(t *testing.T) {
+	r := actions.NewRouter()
+	actions.HandleForm(r.POST("/todos"),
+		func(w http.ResponseWriter, r *http.Request, f todoForm) g.Node {
+			return g.El("li", g.Attr("title", f.Title), g.Attr("data-priority", fmt.Sprint(f.Priority)))
+		},
+		func(f todoForm, errs actions.ValidationErrors) g.Node {
+			return g.El("form", g.Attr("title", f.Title), g.Attr("data-errors", errs.Error()))
+		},
+	)
+
+	t.Run("binds form values", func(t *testing.T) {
+		code, body := post(t, r, "application/x-www-form-urlencoded", "title=Buy+hats&priority=2")
+		if code != http.StatusOK || body != `<li title="Buy hats" data-priority="2"></li>` {
+			t.Fatal("response is", code, body)
+		}
+	})
+
+	t.Run("binds JSON", func(t *testing.T) {
+		code, body := post(t, r, "application/json", `{"title":"Buy hats","priority":2}`)
+		if code != http.StatusOK || body != `<li title="Buy hats" data-priority="2"></li>` {
+			t.Fatal("response is", code, body)
+		}
+	})
+
+	t.Run("renders validation errors with 422", func(t *testing.T) {
+		code, body := post(t, r, "application/x-www-form-urlencoded", "priority=2")
+		if code != http.StatusUnprocessableEntity || body != `<form title="" data-errors="title: is required"></form>` {
+			t.Fatal("response is", code, body)
+		}
+	})
+
+	t.Run("renders binding errors with 422", func(t *testing.T) {
+		code, body := post(t, r, "application/x-www-form-urlencoded", "title=Buy+hats&priority=high")
+		if code != http.StatusUnprocessableEntity || body != `<form title="Buy hats" data-errors="priority: invalid value"></form>` {
+			t.Fatal("response is", code, body)
+		}
+
+		code, body = post(t, r, "application/json", `{"title":"Buy hats","priority":"high"}`)
+		if code != http.StatusUnprocessableEntity || body != `<form title="Buy hats" data-errors="priority: invalid value"></form>` {
+			t.Fatal("response is", code, body)
+		}
+	})
+
+	t.Run("responds with 400 on malformed bodies", func(t *testing.T) {
+		if code, body := post(t, r, "application/json", `{"title":`); code != http.StatusBadRequest || body != "" {
+			t.Fatal("response is", code, body)
+		}
+	})
+}
+
+type signupForm struct {
+	Email string `form:"email"`
+}
+
+func (f *signupForm) Validate() actions.ValidationErrors {
+	if f.Email == "" {
+		return actions.ValidationErrors{"email": "is required"}
+	}
+	return nil
+}
+
+func TestHandleFormWithPointerValidator(t *testing.T) {
+	r := actions.NewRouter()
+	actions.HandleForm(r.POST("/todos"),
+		func(w http.ResponseWriter, r *http.Request, f signupForm) g.Node {
+			return g.El("p", g.Attr("title", f.Email))
+		},
+		func(f signupForm, errs actions.ValidationErrors) g.Node {
+			return g.El("form", g.Attr("data-errors", errs.Error()))
+		},
+	)
+
+	t.Run("validates with a pointer receiver", func(t *testing.T) {
+		code, body := post(t, r, "application/x-www-form-urlencoded", "email=")
+		if code != http.StatusUnprocessableEntity || body != `<form data-errors="email: is required"></form>` {
+			t.Fatal("response is", code, body)
+		}
+
+		code, body = post(t, r, "application/x-www-form-urlencoded", "email=me%40example.com")
+		if code != http.StatusOK || body != `<p title="me@example.com"></p>` {
+			t.Fatal("response is", code, body)
+		}
+	})
+}
+
+func post(t *testing.T, h http.Handler, contentType, body string) (int, string) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/todos", strings.NewReader(body))
+	request.Header.Set("Content-Type", contentType)
+	h.ServeHTTP(recorder, request)
+	result := recorder.Result()
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result.StatusCode, string(b)
+}
@@ -0,0 +1,60 @@
+package actions
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/alarbada/gomponents/hx"
+	"github.com/gin-gonic/gin"
+)
+
+type ginMux struct {
+	engine *gin.Engine
+}
+
+// Gin adapts a gin engine to a Mux.
+// Patterns are converted to gin's syntax, so "/todos/{id}" becomes "/todos/:id" and "/files/{path...}" becomes
+// "/files/*path". The gin path parameters are available through http.Request.PathValue.
+func Gin(e *gin.Engine) Mux {
+	return ginMux{engine: e}
+}
+
+func (m ginMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.engine.ServeHTTP(w, r)
+}
+
+func (m ginMux) Handle(method, pattern string, h http.Handler) {
+	m.engine.Handle(method, ginPattern(pattern), func(c *gin.Context) {
+		for _, p := range c.Params {
+			// gin includes the leading slash in catch-all parameters, http.ServeMux doesn't.
+			c.Request.SetPathValue(p.Key, strings.TrimPrefix(p.Value, "/"))
+		}
+		h.ServeHTTP(c.Writer, c.Request)
+	})
+}
+
+// ginPattern converts a http.ServeMux pattern to gin's syntax.
+func ginPattern(pattern string) string {
+	segments := strings.Split(pattern, "/")
+	for i, s := range segments {
+		if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+			continue
+		}
+
+		name := s[1 : len(s)-1]
+		switch {
+		case name == "$":
+			segments[i] = ""
+		case strings.HasSuffix(name, "..."):
+			segments[i] = "*" + strings.TrimSuffix(name, "...")
+		default:
+			segments[i] = ":" + name
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// HxRequest returns the htmx request headers of the request in c.
+func HxRequest(c *gin.Context) hx.RequestInfo {
+	return hx.Request(c.Request)
+}
@@ -0,0 +1,197 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	g "github.com/alarbada/gomponents"
+)
+
+// Params are the values of the path wildcards of a named action, by wildcard name.
+type Params map[string]string
+
+// Name the action, so that its URL can be built with Router.URL from anywhere.
+// The full name is prefixed with the names of the router groups, separated by dots:
+// an action named "show" in a group named "user" is "user.show". Groups must be named before their actions.
+// It panics if another action of the router tree already has the full name.
+func (a *Action) Name(name string) *Action {
+	names := []string{name}
+	for r := a.router; r != nil; r = r.parent {
+		if r.name != "" {
+			names = append([]string{r.name}, names...)
+		}
+	}
+	full := strings.Join(names, ".")
+
+	root := a.router.root()
+	if _, ok := root.names[full]; ok {
+		panic(fmt.Sprintf("action name %v is already used", full))
+	}
+	if root.names == nil {
+		root.names = map[string]*Action{}
+	}
+	root.names[full] = a
+	a.name = full
+	return a
+}
+
+// Name the action. See Action.Name.
+func (a *TypedAction[P]) Name(name string) *TypedAction[P] {
+	a.action.Name(name)
+	return a
+}
+
+// URL of the named action, with the path wildcards filled in from params and escaped.
+// Names are the full names, including the group names, from any router of the tree.
+// It panics if there's no action with the name, or if params don't have exactly the wildcards of its path.
+// Use Ref for URLs in pages rendered before all actions are registered.
+func (r *Router) URL(name string, params Params) string {
+	u, err := r.url(name, params)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// Hx attribute for the named action with its URL, like hx-get="/users/1". See URL.
+func (r *Router) Hx(name string, params Params) g.Node {
+	a := r.named(name)
+	return g.Attr("hx-"+strings.ToLower(a.Method), r.URL(name, params))
+}
+
+// Href attribute for the named action with its URL, like href="/users/1". See URL.
+func (r *Router) Href(name string, params Params) g.Node {
+	return g.Attr("href", r.URL(name, params))
+}
+
+func (r *Router) named(name string) *Action {
+	a, ok := r.root().names[name]
+	if !ok {
+		panic(fmt.Sprintf("no action named %v", name))
+	}
+	return a
+}
+
+func (r *Router) url(name string, params Params) (string, error) {
+	a, ok := r.root().names[name]
+	if !ok {
+		return "", fmt.Errorf("no action named %v", name)
+	}
+
+	pattern := a.pattern()
+	wildcards := patternWildcards(pattern)
+	for _, w := range wildcards {
+		if _, ok := params[w]; !ok {
+			return "", fmt.Errorf("action %v: missing parameter %v for %v", name, w, pattern)
+		}
+	}
+	var extra []string
+	for p := range params {
+		if !contains(wildcards, p) {
+			extra = append(extra, p)
+		}
+	}
+	if len(extra) > 0 {
+		sort.Strings(extra)
+		return "", fmt.Errorf("action %v: parameters %v are not wildcards in %v", name, strings.Join(extra, ", "), pattern)
+	}
+
+	return fillPattern(pattern, params), nil
+}
+
+func (r *Router) root() *Router {
+	for r.parent != nil {
+		r = r.parent
+	}
+	return r
+}
+
+// Ref is a reference to a named action, that is resolved when it's used.
+// Refs are recorded by the router, so that Router.Check can verify them all at startup.
+type Ref struct {
+	router *Router
+	name   string
+	params Params
+}
+
+// Ref to the named action with params. Unlike URL, it doesn't need the action to be registered yet,
+// so it can be used in package-level variables and in components declared before the routes.
+// Create Refs in package-level or setup code, not while rendering requests: they are only recorded for Check
+// until Check is first called, and equal Refs are recorded once.
+func (r *Router) Ref(name string, params Params) Ref {
+	ref := Ref{router: r.root(), name: name, params: params}
+	ref.router.recordRef(ref)
+	return ref
+}
+
+// recordRef for Check, unless it's recorded already or Check has been called.
+func (r *Router) recordRef(ref Ref) {
+	r.refsLock.Lock()
+	defer r.refsLock.Unlock()
+
+	if r.refsChecked {
+		return
+	}
+	key := ref.key()
+	if _, ok := r.refKeys[key]; ok {
+		return
+	}
+	if r.refKeys == nil {
+		r.refKeys = map[string]struct{}{}
+	}
+	r.refKeys[key] = struct{}{}
+	r.refs = append(r.refs, ref)
+}
+
+// key identifying the name and params of the Ref.
+func (r Ref) key() string {
+	keys := make([]string, 0, len(r.params))
+	for k := range r.params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(r.name)
+	for _, k := range keys {
+		b.WriteString("\x00" + k + "=" + r.params[k])
+	}
+	return b.String()
+}
+
+// URL of the referenced action. See Router.URL.
+func (r Ref) URL() string {
+	return r.router.URL(r.name, r.params)
+}
+
+// Hx attribute for the referenced action. See Router.Hx.
+func (r Ref) Hx() g.Node {
+	return r.router.Hx(r.name, r.params)
+}
+
+// Href attribute for the referenced action. See Router.Href.
+func (r Ref) Href() g.Node {
+	return r.router.Href(r.name, r.params)
+}
+
+// Check that every Ref created on the router tree names a registered action, with parameters matching
+// its path wildcards. Call it after registering all actions, so renamed or removed routes fail at startup
+// instead of leaving dead links in rendered pages. All problems are returned together.
+// Refs created after the first Check are not recorded anymore.
+func (r *Router) Check() error {
+	root := r.root()
+	root.refsLock.Lock()
+	root.refsChecked = true
+	refs := root.refs
+	root.refsLock.Unlock()
+
+	var errs []error
+	for _, ref := range refs {
+		if _, err := ref.router.url(ref.name, ref.params); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
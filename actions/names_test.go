@@ -0,0 +1,132 @@
+package actions_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alarbada/gomponents/actions"
+	"github.com/alarbada/gomponents/internal/assert"
+)
+
+func TestNames(t *testing.T) {
+	t.Run("builds URLs and attributes for named actions", func(t *testing.T) {
+		r := actions.NewRouter()
+		r.GET("/users/{id}").Name("user.show")
+		r.DELETE("/users/{id}").Name("user.delete")
+		r.GET("/files/{path...}").Name("file")
+
+		if u := r.URL("user.show", actions.Params{"id": "a b"}); u != "/users/a%20b" {
+			t.Fatal("URL is", u)
+		}
+		if u := r.URL("file", actions.Params{"path": "a/b c.txt"}); u != "/files/a/b%20c.txt" {
+			t.Fatal("URL is", u)
+		}
+		assert.Equal(t, ` hx-delete="/users/1"`, r.Hx("user.delete", actions.Params{"id": "1"}))
+		assert.Equal(t, ` href="/users/1"`, r.Href("user.show", actions.Params{"id": "1"}))
+	})
+
+	t.Run("prefixes names with group names", func(t *testing.T) {
+		r := actions.NewRouter()
+		admin := r.Group("/admin").Name("admin")
+		admin.Group("/users").Name("user").GET("/{id}").Name("show")
+		admin.Group("/unnamed").GET("/").Name("index")
+
+		if u := r.URL("admin.user.show", actions.Params{"id": "1"}); u != "/admin/users/1" {
+			t.Fatal("URL is", u)
+		}
+		if u := admin.URL("admin.index", nil); u != "/admin/unnamed/" {
+			t.Fatal("URL is", u)
+		}
+	})
+
+	t.Run("panics on unknown names and mismatched parameters", func(t *testing.T) {
+		r := actions.NewRouter()
+		r.GET("/users/{id}").Name("user.show")
+
+		cases := map[string]func(){
+			"unknown name":      func() { r.URL("user.edit", nil) },
+			"missing parameter": func() { r.URL("user.show", nil) },
+			"extra parameter":   func() { r.URL("user.show", actions.Params{"id": "1", "sort": "name"}) },
+			"duplicate name":    func() { r.GET("/people/{id}").Name("user.show") },
+		}
+		for name, fn := range cases {
+			t.Run(name, func(t *testing.T) {
+				defer func() {
+					if recover() == nil {
+						t.Fatal("did not panic")
+					}
+				}()
+				fn()
+			})
+		}
+	})
+
+	t.Run("resolves refs when used and checks them all", func(t *testing.T) {
+		r := actions.NewRouter()
+		show := r.Ref("user.show", actions.Params{"id": "1"})
+		r.Ref("user.edit", nil)
+		r.Ref("user.show", nil)
+		r.Group("/api").Ref("user.show", actions.Params{"id": "1", "user": "1"})
+
+		r.GET("/users/{id}").Name("user.show")
+
+		assert.Equal(t, ` hx-get="/users/1"`, show.Hx())
+		assert.Equal(t, ` href="/users/1"`, show.Href())
+
+		err := r.Check()
+		if err == nil {
+			t.Fatal("no error")
+		}
+		for _, s := range []string{"no action named user.edit", "missing parameter id", "parameters user are not wildcards"} {
+			if !strings.Contains(err.Error(), s) {
+				t.Fatal("error is", err)
+			}
+		}
+	})
+
+	t.Run("reports equal refs once", func(t *testing.T) {
+		r := actions.NewRouter()
+		for i := 0; i < 3; i++ {
+			r.Ref("x", nil)
+			r.Ref("y", actions.Params{"id": "1", "sort": "name"})
+		}
+
+		err := r.Check()
+		if err == nil || strings.Count(err.Error(), "\n") != 1 {
+			t.Fatal("error is", err)
+		}
+	})
+
+	t.Run("stops recording refs after the first check", func(t *testing.T) {
+		r := actions.NewRouter()
+		r.GET("/users/{id}").Name("user.show")
+		if err := r.Check(); err != nil {
+			t.Fatal(err)
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r.Ref("x", nil)
+			}()
+		}
+		wg.Wait()
+
+		if err := r.Check(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("checks without error if all refs match", func(t *testing.T) {
+		r := actions.NewRouter()
+		r.Ref("user.show", actions.Params{"id": "1"})
+		r.GET("/users/{id}").Name("user.show")
+
+		if err := r.Check(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
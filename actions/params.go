@@ -0,0 +1,237 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// paramField is a struct field filled from a request value.
+type paramField struct {
+	index []int
+	name  string
+}
+
+// params describes how to fill a struct type P from path and query parameters,
+// given by fields with "path" and "query" tags.
+type params struct {
+	typ   reflect.Type
+	path  []paramField
+	query []paramField
+}
+
+var wildcardPattern = regexp.MustCompile(`\{([^}]*)\}`)
+
+// newParams for struct type P and the route pattern. It panics if P is not a struct, if a field has an unsupported
+// type, or if the path fields don't match the wildcards in the pattern.
+func newParams[P any](pattern string) params {
+	typ := reflect.TypeOf((*P)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("parameters type %v must be a struct", typ))
+	}
+
+	p := params{typ: typ, path: tagFields(typ, "path", false), query: tagFields(typ, "query", true)}
+
+	wildcards := patternWildcards(pattern)
+	if len(wildcards) != len(p.path) {
+		panic(fmt.Sprintf("path fields of %v don't match the wildcards in %v", typ, pattern))
+	}
+	for _, f := range p.path {
+		if !contains(wildcards, f.name) {
+			panic(fmt.Sprintf("path field %v of %v is not a wildcard in %v", f.name, typ, pattern))
+		}
+	}
+
+	return p
+}
+
+// tagFields returns the exported fields of the struct type with the given tag.
+// It panics if a field has an unsupported type.
+func tagFields(typ reflect.Type, tag string, allowSlice bool) []paramField {
+	var fields []paramField
+	for _, f := range reflect.VisibleFields(typ) {
+		if !f.IsExported() {
+			continue
+		}
+		if name, ok := f.Tag.Lookup(tag); ok {
+			checkKind(f.Type, allowSlice)
+			fields = append(fields, paramField{index: f.Index, name: name})
+		}
+	}
+	return fields
+}
+
+// patternWildcards returns the names of the wildcards in pattern, without "{$}".
+func patternWildcards(pattern string) []string {
+	var names []string
+	for _, m := range wildcardPattern.FindAllStringSubmatch(pattern, -1) {
+		if m[1] != "$" {
+			names = append(names, strings.TrimSuffix(m[1], "..."))
+		}
+	}
+	return names
+}
+
+// decode the path values and query parameters of r into a new value of the struct type.
+func (p params) decode(r *http.Request) (reflect.Value, error) {
+	v := reflect.New(p.typ).Elem()
+	for _, f := range p.path {
+		if err := setField(v.FieldByIndex(f.index), []string{r.PathValue(f.name)}); err != nil {
+			return v, fmt.Errorf("path parameter %v: %w", f.name, err)
+		}
+	}
+
+	query := r.URL.Query()
+	for _, f := range p.query {
+		values, ok := query[f.name]
+		if !ok {
+			continue
+		}
+		if err := setField(v.FieldByIndex(f.index), values); err != nil {
+			return v, fmt.Errorf("query parameter %v: %w", f.name, err)
+		}
+	}
+	return v, nil
+}
+
+// url for the pattern with the wildcards filled from v, and query parameters for the non-zero query fields.
+func (p params) url(pattern string, v reflect.Value) string {
+	values := map[string]string{}
+	for _, f := range p.path {
+		values[f.name] = formatValue(v.FieldByIndex(f.index))[0]
+	}
+
+	u := fillPattern(pattern, values)
+
+	query := url.Values{}
+	for _, f := range p.query {
+		fv := v.FieldByIndex(f.index)
+		if fv.IsZero() {
+			continue
+		}
+		query[f.name] = formatValue(fv)
+	}
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// fillPattern replaces the wildcards in pattern with the escaped values. "{$}" is removed.
+func fillPattern(pattern string, values map[string]string) string {
+	return wildcardPattern.ReplaceAllStringFunc(pattern, func(wildcard string) string {
+		name := wildcard[1 : len(wildcard)-1]
+		switch {
+		case name == "$":
+			return ""
+		case strings.HasSuffix(name, "..."):
+			segments := strings.Split(values[strings.TrimSuffix(name, "...")], "/")
+			for i, s := range segments {
+				segments[i] = url.PathEscape(s)
+			}
+			return strings.Join(segments, "/")
+		default:
+			return url.PathEscape(values[name])
+		}
+	})
+}
+
+func checkKind(t reflect.Type, allowSlice bool) {
+	if allowSlice && t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+	default:
+		panic(fmt.Sprintf("unsupported parameter type %v", t))
+	}
+}
+
+// setField to values, parsed according to the type of the field.
+// Slice fields get all values, other fields the first.
+func setField(v reflect.Value, values []string) error {
+	if v.Kind() != reflect.Slice {
+		return setValue(v, values[0])
+	}
+
+	s := reflect.MakeSlice(v.Type(), len(values), len(values))
+	for i, value := range values {
+		if err := setValue(s.Index(i), value); err != nil {
+			return err
+		}
+	}
+	v.Set(s)
+	return nil
+}
+
+func setValue(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	}
+	return nil
+}
+
+// formatValue as strings, one for each element of slices.
+func formatValue(v reflect.Value) []string {
+	if v.Kind() == reflect.Slice {
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = formatValue(v.Index(i))[0]
+		}
+		return values
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return []string{strconv.FormatBool(v.Bool())}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []string{strconv.FormatInt(v.Int(), 10)}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []string{strconv.FormatUint(v.Uint(), 10)}
+	case reflect.Float32, reflect.Float64:
+		return []string{strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())}
+	default:
+		return []string{v.String()}
+	}
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
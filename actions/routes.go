@@ -0,0 +1,93 @@
+package actions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+)
+
+// Route describes a registered action.
+type Route struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Name    string `json:"name,omitempty"`
+	Handler string `json:"handler"`
+	// Middleware are the names of the middleware functions of the action, outermost first.
+	Middleware []string `json:"middleware,omitempty"`
+}
+
+// Routes returns every action registered on the router tree, in registration order,
+// with its full path and name, and the names of its handler and middleware functions.
+// Anonymous handlers are named after the function they're declared in, like "main.main.func1".
+func (r *Router) Routes() []Route {
+	var routes []Route
+	for _, a := range r.root().actions {
+		routes = append(routes, Route{
+			Method:     a.Method,
+			Path:       a.pattern(),
+			Name:       a.name,
+			Handler:    a.handler,
+			Middleware: a.middlewareNames(),
+		})
+	}
+	return routes
+}
+
+// RoutesHandler responds with the routes of the router tree as JSON, which the routes command can print.
+// It lists every endpoint of the application, so only serve it where that's fine, like on an internal port.
+func (r *Router) RoutesHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = PrintRoutes(w, r.Routes(), "json")
+	})
+}
+
+// PrintRoutes to w in the format "table", with aligned columns, or "json".
+func PrintRoutes(w io.Writer, routes []Route, format string) error {
+	switch format {
+	case "table":
+		var b bytes.Buffer
+		tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "METHOD\tPATH\tNAME\tHANDLER\tMIDDLEWARE")
+		for _, r := range routes {
+			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\n", r.Method, r.Path, r.Name, r.Handler, strings.Join(r.Middleware, ", "))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		// Trim the padding of empty cells at the end of lines.
+		for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+			if _, err := io.WriteString(w, strings.TrimRight(line, " ")+"\n"); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "json":
+		if routes == nil {
+			routes = []Route{}
+		}
+		e := json.NewEncoder(w)
+		e.SetIndent("", "  ")
+		return e.Encode(routes)
+	default:
+		return fmt.Errorf("unknown routes format %v", format)
+	}
+}
+
+// funcName of the function f.
+func funcName(f any) string {
+	v := reflect.ValueOf(f)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return ""
+	}
+	if fn := runtime.FuncForPC(v.Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package actions_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/actions"
+)
+
+func showTodo(w http.ResponseWriter, r *http.Request) g.Node {
+	return nil
+}
+
+func TestRoutes(t *testing.T) {
+	r := actions.NewRouter()
+	r.GET("/todos/{id}").Name("todo.show").Handle(showTodo)
+	r.Group("/api", requireLogin).POST("/todos").Handle(func(w http.ResponseWriter, r *http.Request) g.Node {
+		return nil
+	})
+	r.GET("/unhandled")
+
+	want := []actions.Route{
+		{Method: "GET", Path: "/todos/{id}", Name: "todo.show", Handler: "github.com/alarbada/gomponents/actions_test.showTodo"},
+		{Method: "POST", Path: "/api/todos", Handler: "github.com/alarbada/gomponents/actions_test.TestRoutes.func1",
+			Middleware: []string{"github.com/alarbada/gomponents/actions_test.requireLogin"}},
+	}
+
+	t.Run("lists handled actions", func(t *testing.T) {
+		if routes := r.Group("/api").Routes(); !reflect.DeepEqual(want, routes) {
+			t.Fatalf("routes are %#v", routes)
+		}
+	})
+
+	t.Run("prints routes as a table", func(t *testing.T) {
+		var b strings.Builder
+		if err := actions.PrintRoutes(&b, want, "table"); err != nil {
+			t.Fatal(err)
+		}
+		expected := "METHOD  PATH         NAME       HANDLER                                                       MIDDLEWARE\n" +
+			"GET     /todos/{id}  todo.show  github.com/alarbada/gomponents/actions_test.showTodo\n" +
+			"POST    /api/todos              github.com/alarbada/gomponents/actions_test.TestRoutes.func1  github.com/alarbada/gomponents/actions_test.requireLogin\n"
+		if b.String() != expected {
+			t.Fatalf("table is\n%v", b.String())
+		}
+	})
+
+	t.Run("serves routes as JSON", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		r.RoutesHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		var routes []actions.Route
+		if err := json.NewDecoder(recorder.Body).Decode(&routes); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(want, routes) {
+			t.Fatalf("routes are %#v", routes)
+		}
+	})
+
+	t.Run("errors on unknown formats", func(t *testing.T) {
+		if err := actions.PrintRoutes(&strings.Builder{}, want, "yaml"); err == nil {
+			t.Fatal("no error")
+		}
+	})
+}
@@ -0,0 +1,18 @@
+package actions
+
+import (
+	"net/http"
+)
+
+type serveMux struct {
+	*http.ServeMux
+}
+
+// ServeMux adapts a http.ServeMux to a Mux.
+func ServeMux(m *http.ServeMux) Mux {
+	return serveMux{m}
+}
+
+func (m serveMux) Handle(method, pattern string, h http.Handler) {
+	m.ServeMux.Handle(method+" "+pattern, h)
+}
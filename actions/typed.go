@@ -0,0 +1,81 @@
+package actions
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	g "github.com/alarbada/gomponents"
+)
+
+// TypedAction is an Action with path and query parameters parsed into the struct type P.
+// Fields of P with a "path" tag are filled from the path wildcard with that name,
+// and fields with a "query" tag from the query parameter with that name:
+//
+//	type TodoParams struct {
+//		ID   int    `path:"id"`
+//		Sort string `query:"sort"`
+//	}
+//
+//	show := actions.GET[TodoParams](r, "/todos/{id}")
+//
+// Parameter fields can be strings, booleans, integers, or floats, and query fields also slices of those.
+type TypedAction[P any] struct {
+	action *Action
+	params params
+}
+
+func typedAction[P any](r *Router, method, path string) *TypedAction[P] {
+	a := r.action(method, path)
+	return &TypedAction[P]{action: a, params: newParams[P](a.pattern())}
+}
+
+// GET creates a TypedAction on the router. It panics if P is not a struct with supported field types,
+// or if its path fields don't match the wildcards in the path.
+func GET[P any](r *Router, path string) *TypedAction[P]    { return typedAction[P](r, "GET", path) }
+func POST[P any](r *Router, path string) *TypedAction[P]   { return typedAction[P](r, "POST", path) }
+func PUT[P any](r *Router, path string) *TypedAction[P]    { return typedAction[P](r, "PUT", path) }
+func DELETE[P any](r *Router, path string) *TypedAction[P] { return typedAction[P](r, "DELETE", path) }
+func PATCH[P any](r *Router, path string) *TypedAction[P]  { return typedAction[P](r, "PATCH", path) }
+
+// Action that the TypedAction is registered as.
+func (a *TypedAction[P]) Action() *Action {
+	return a.action
+}
+
+// Handle registers the action handler, which gets the parsed parameters.
+// If the parameters can't be parsed, the handler isn't called, and the response is a 400 Bad Request,
+// with the Node of the router's ErrorRenderer.
+func (a *TypedAction[P]) Handle(action func(w http.ResponseWriter, r *http.Request, params P) g.Node) *TypedAction[P] {
+	a.handle(action, func(w http.ResponseWriter, r *http.Request, params P) (g.Node, error) {
+		return action(w, r, params), nil
+	})
+	return a
+}
+
+// HandleErr registers an action handler that gets the parsed parameters, and can return an error.
+// Errors are handled like for Action.HandleErr, and parameters that can't be parsed like for Handle.
+func (a *TypedAction[P]) HandleErr(action func(w http.ResponseWriter, r *http.Request, params P) (g.Node, error)) *TypedAction[P] {
+	a.handle(action, action)
+	return a
+}
+
+func (a *TypedAction[P]) handle(handler any, action func(w http.ResponseWriter, r *http.Request, params P) (g.Node, error)) {
+	a.action.handle(handler, func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+		v, err := a.params.decode(r)
+		if err != nil {
+			return nil, badRequestError{err}
+		}
+		return action(w, r, v.Interface().(P))
+	})
+}
+
+// URL for the action, with the path wildcards filled in and escaped, and the non-zero query fields encoded.
+func (a *TypedAction[P]) URL(params P) string {
+	return a.params.url(a.action.pattern(), reflect.ValueOf(params))
+}
+
+// Hx attribute for the action with its URL, like hx-get="/todos/1".
+func (a *TypedAction[P]) Hx(params P) g.Node {
+	return g.Attr("hx-"+strings.ToLower(a.action.Method), a.URL(params))
+}
@@ -0,0 +1,122 @@
+package actions_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/actions"
+	"github.com/alarbada/gomponents/internal/assert"
+)
+
+type todoParams struct {
+	ListID string   `path:"list"`
+	ID     int      `path:"id"`
+	Sort   string   `query:"sort"`
+	Done   bool     `query:"done"`
+	Tags   []string `query:"tag"`
+}
+
+func TestTypedAction(t *testing.T) {
+	t.Run("parses path and query parameters", func(t *testing.T) {
+		r := actions.NewRouter()
+		actions.GET[todoParams](r, "/lists/{list}/todos/{id}").Handle(func(w http.ResponseWriter, r *http.Request, p todoParams) g.Node {
+			return g.El("p", g.Attr("title", fmt.Sprintf("%+v", p)))
+		})
+
+		_, body := request(t, r, http.MethodGet, "/lists/home/todos/3?sort=due&done=true&tag=a&tag=b")
+		if body != `<p title="{ListID:home ID:3 Sort:due Done:true Tags:[a b]}"></p>` {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("responds with 400 on invalid parameters", func(t *testing.T) {
+		r := actions.NewRouter()
+		actions.GET[todoParams](r, "/lists/{list}/todos/{id}").Handle(func(w http.ResponseWriter, r *http.Request, p todoParams) g.Node {
+			t.Fatal("handler called")
+			return nil
+		})
+
+		if code, _ := request(t, r, http.MethodGet, "/lists/home/todos/abc"); code != http.StatusBadRequest {
+			t.Fatal("status code is", code)
+		}
+		if code, _ := request(t, r, http.MethodGet, "/lists/home/todos/1?done=maybe"); code != http.StatusBadRequest {
+			t.Fatal("status code is", code)
+		}
+	})
+
+	t.Run("renders invalid parameter errors with the error renderer", func(t *testing.T) {
+		r := actions.NewRouter()
+		r.ErrorRenderer = func(r *http.Request, err error) g.Node {
+			return g.El("p", g.Attr("class", "error"))
+		}
+		actions.GET[todoParams](r, "/lists/{list}/todos/{id}").Handle(func(w http.ResponseWriter, r *http.Request, p todoParams) g.Node {
+			return nil
+		})
+
+		if code, body := request(t, r, http.MethodGet, "/lists/home/todos/abc"); code != http.StatusBadRequest || body != `<p class="error"></p>` {
+			t.Fatal("response is", code, body)
+		}
+	})
+
+	t.Run("maps handler errors to status codes", func(t *testing.T) {
+		r := actions.NewRouter()
+		actions.GET[todoParams](r, "/lists/{list}/todos/{id}").HandleErr(func(w http.ResponseWriter, r *http.Request, p todoParams) (g.Node, error) {
+			if p.ID != 1 {
+				return g.El("p", g.Attr("class", "missing")), statusError(http.StatusNotFound)
+			}
+			return g.El("p", g.Attr("title", p.ListID)), nil
+		})
+
+		if code, body := request(t, r, http.MethodGet, "/lists/home/todos/1"); code != http.StatusOK || body != `<p title="home"></p>` {
+			t.Fatal("response is", code, body)
+		}
+		if code, body := request(t, r, http.MethodGet, "/lists/home/todos/2"); code != http.StatusNotFound || body != `<p class="missing"></p>` {
+			t.Fatal("response is", code, body)
+		}
+	})
+
+	t.Run("builds escaped URLs and hx attributes", func(t *testing.T) {
+		r := actions.NewRouter().Group("/api")
+		a := actions.DELETE[todoParams](r, "/lists/{list}/todos/{id}")
+
+		url := a.URL(todoParams{ListID: "a b/c", ID: 3, Tags: []string{"x&y"}})
+		if url != "/api/lists/a%20b%2Fc/todos/3?tag=x%26y" {
+			t.Fatal("url is", url)
+		}
+		assert.Equal(t, ` hx-delete="/api/lists/home/todos/1?done=true&amp;sort=due"`,
+			a.Hx(todoParams{ListID: "home", ID: 1, Sort: "due", Done: true}))
+	})
+
+	t.Run("keeps slashes in remaining path wildcards", func(t *testing.T) {
+		type fileParams struct {
+			Path string `path:"path"`
+		}
+		a := actions.GET[fileParams](actions.NewRouter(), "/files/{path...}")
+		if url := a.URL(fileParams{Path: "docs/read me.txt"}); url != "/files/docs/read%20me.txt" {
+			t.Fatal("url is", url)
+		}
+	})
+
+	t.Run("panics if path fields don't match the pattern", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("did not panic")
+			}
+		}()
+		actions.GET[todoParams](actions.NewRouter(), "/todos/{id}")
+	})
+
+	t.Run("panics on unsupported field types", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("did not panic")
+			}
+		}()
+		type badParams struct {
+			ID map[string]string `query:"id"`
+		}
+		actions.GET[badParams](actions.NewRouter(), "/")
+	})
+}
@@ -0,0 +1,55 @@
+// Command routes prints the routes of an actions.Router, as served by Router.RoutesHandler.
+//
+// Usage:
+//
+//	routes [-format table|json] [url]
+//
+// Routes are read from the URL, or from standard input if no URL is given, so a saved listing can be printed
+// and compared with another one in code review.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/alarbada/gomponents/actions"
+)
+
+func main() {
+	format := flag.String("format", "table", "output format, table or json")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: routes [-format table|json] [url]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(os.Stdout, os.Stdin, flag.Arg(0), *format); err != nil {
+		fmt.Fprintln(os.Stderr, "routes:", err)
+		os.Exit(1)
+	}
+}
+
+func run(w io.Writer, stdin io.Reader, url, format string) error {
+	in := stdin
+	if url != "" {
+		res, err := http.Get(url)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return fmt.Errorf("getting %v: %v", url, res.Status)
+		}
+		in = res.Body
+	}
+
+	var routes []actions.Route
+	if err := json.NewDecoder(in).Decode(&routes); err != nil {
+		return fmt.Errorf("reading routes: %w", err)
+	}
+	return actions.PrintRoutes(w, routes, format)
+}
@@ -66,60 +66,142 @@ func (n NodeFunc) String() string {
 // If an element is a void element, non-attribute children nodes are ignored.
 // Use this if no convenience creator exists.
 func El(name string, children ...Node) Node {
-	return NodeFunc(func(w2 io.Writer) error {
-		w := &statefulWriter{w: w2}
+	return &element{name: name, children: children}
+}
 
-		w.WriteString("<")
-		w.WriteString(name)
+type element struct {
+	name     string
+	children []Node
+}
 
-		classValues := []string{}
-		for _, c := range children {
-			renderAttributes(w, c, &classValues)
-		}
+// Render satisfies Node.
+func (e *element) Render(w2 io.Writer) error {
+	w := &statefulWriter{w: w2}
+
+	w.WriteString("<")
+	w.WriteString(e.name)
+
+	var merged mergedAttrs
+	for _, c := range e.children {
+		renderAttributes(w, c, &merged)
+	}
 
-		if l := len(classValues); l > 0 {
-			w.WriteString(` class="`)
-			for i, v := range classValues {
-				w.WriteString(v)
-				if i < l-1 {
-					w.WriteString(" ")
-				}
+	if l := len(merged.class); l > 0 {
+		w.WriteString(` class="`)
+		for i, v := range merged.class {
+			w.WriteString(v)
+			if i < l-1 {
+				w.WriteString(" ")
 			}
-			w.WriteString(`"`)
 		}
+		w.WriteString(`"`)
+	}
+
+	for _, name := range merged.names {
+		w.WriteString(" ")
+		w.WriteString(name)
+		w.WriteString(`="`)
+		w.WriteString(template.HTMLEscapeString(merged.mergers[name].Merge(merged.values[name])))
+		w.WriteString(`"`)
+	}
 
-		w.WriteString(">")
+	w.WriteString(">")
 
-		if isVoidElement(name) {
-			return w.err
-		}
+	if isVoidElement(e.name) {
+		return w.err
+	}
 
-		for _, c := range children {
-			renderChild(w, c)
+	for _, c := range e.children {
+		renderChild(w, c)
+	}
+
+	w.WriteString("</")
+	w.WriteString(e.name)
+	w.WriteString(">")
+
+	return w.err
+}
+
+func (e *element) Type() NodeType {
+	return ElementType
+}
+
+// String satisfies fmt.Stringer.
+func (e *element) String() string {
+	var b strings.Builder
+	_ = e.Render(&b)
+	return b.String()
+}
+
+// attrValue returns the value of the first attribute child with the given name, searching through groups.
+func attrValue(children []Node, name string) (string, bool) {
+	for _, c := range children {
+		switch v := c.(type) {
+		case group:
+			if value, ok := attrValue(v.children, name); ok {
+				return value, true
+			}
+		case *attr:
+			if v.name == name && v.value != nil {
+				return *v.value, true
+			}
 		}
+	}
+	return "", false
+}
 
-		w.WriteString("</")
-		w.WriteString(name)
-		w.WriteString(">")
+// MergedAttr is implemented by Nodes of AttributeType that are rendered once per element, even if given more than once.
+// The values of all the MergedAttr Nodes with the same name on an element are passed to Merge of the first one,
+// in the order they're given, and the result is rendered as the value of a single attribute.
+// Merged attributes are rendered after the other attributes and class, in the order they're first given.
+// The class attribute created with Attr is always merged, by joining its values with spaces.
+type MergedAttr interface {
+	TypedNode
+	AttrName() string
+	AttrValue() string
+	Merge(values []string) string
+}
 
-		return w.err
-	})
+// mergedAttrs has the values of attributes that are rendered once per element.
+type mergedAttrs struct {
+	class   []string
+	names   []string
+	values  map[string][]string
+	mergers map[string]MergedAttr
+}
+
+func (m *mergedAttrs) add(a MergedAttr) {
+	name := a.AttrName()
+	if m.values == nil {
+		m.values = map[string][]string{}
+		m.mergers = map[string]MergedAttr{}
+	}
+	if _, ok := m.mergers[name]; !ok {
+		m.names = append(m.names, name)
+		m.mergers[name] = a
+	}
+	m.values[name] = append(m.values[name], a.AttrValue())
 }
 
-func renderAttributes(w *statefulWriter, n Node, classValues *[]string) {
+func renderAttributes(w *statefulWriter, n Node, merged *mergedAttrs) {
 	if w.err != nil || n == nil {
 		return
 	}
 
 	if g, ok := n.(group); ok {
 		for _, groupC := range g.children {
-			renderAttributes(w, groupC, classValues)
+			renderAttributes(w, groupC, merged)
 		}
 		return
 	}
 
-	if attr, ok := n.(*attr); ok && attr.name == "class" {
-		*classValues = append(*classValues, *attr.value)
+	if attr, ok := n.(*attr); ok && attr.value != nil && attr.name == "class" {
+		merged.class = append(merged.class, *attr.value)
+		return
+	}
+
+	if m, ok := n.(MergedAttr); ok {
+		merged.add(m)
 		return
 	}
 
@@ -315,13 +397,85 @@ func (f *fragment) Render(w io.Writer) error {
 	return nil
 }
 
-// Fragment groups multiple nodes into one Node. Kind of like React.Fragment. 
+// Fragment groups multiple nodes into one Node. Kind of like React.Fragment.
 // It has an easier api than Group for rendering a collection of nodes without
 // specifiying a parent element.
 func Fragment(children ...Node) Node {
 	return &fragment{children: children}
 }
 
+// SelectByID returns the element Node with the given id attribute found in the tree of n, and true.
+// If there is no such element, it returns nil and false.
+// Only elements created with El, and Nodes created with Group and Fragment, are searched.
+// Other Nodes, such as a NodeFunc, are opaque and their output is not inspected.
+// Nothing is rendered while searching, so the result can be rendered on its own, for example to respond
+// to a partial htmx request with just the targeted part of a full page.
+func SelectByID(n Node, id string) (Node, bool) {
+	var children []Node
+	switch v := n.(type) {
+	case *element:
+		if value, ok := attrValue(v.children, "id"); ok && value == id {
+			return v, true
+		}
+		children = v.children
+	case group:
+		children = v.children
+	case *fragment:
+		children = v.children
+	}
+
+	for _, c := range children {
+		if found, ok := SelectByID(c, id); ok {
+			return found, true
+		}
+	}
+	return nil, false
+}
+
+// ElementID returns the id attribute value of an element Node created with El, and true.
+// It returns false if n is not such an element, or if it has no id attribute.
+func ElementID(n Node) (string, bool) {
+	e, ok := n.(*element)
+	if !ok {
+		return "", false
+	}
+	return attrValue(e.children, "id")
+}
+
+// AppendChildren returns a copy of an element Node created with El, with the given children added, and true.
+// Use it to add attributes to an element created elsewhere.
+// If n is not an element created with El, it returns n unchanged and false.
+func AppendChildren(n Node, children ...Node) (Node, bool) {
+	e, ok := n.(*element)
+	if !ok {
+		return n, false
+	}
+
+	all := make([]Node, 0, len(e.children)+len(children))
+	all = append(all, e.children...)
+	all = append(all, children...)
+	return &element{name: e.name, children: all}, true
+}
+
+// ElementContent returns a Node that renders only the content of an element Node created with El,
+// without its start and end tags and attributes, and true.
+// Use it to respond with what goes inside an element, like htmx swaps into its target by default.
+// If n is not an element created with El, it returns nil and false.
+func ElementContent(n Node) (Node, bool) {
+	e, ok := n.(*element)
+	if !ok {
+		return nil, false
+	}
+
+	return NodeFunc(func(w io.Writer) error {
+		sw := &statefulWriter{w: w}
+		for _, c := range e.children {
+			renderChild(sw, c)
+		}
+		return sw.err
+	}), true
+}
+
 func Static(children ...Node) Node {
 	var sb strings.Builder
 	err := Fragment(children...).Render(&sb)
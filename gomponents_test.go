@@ -128,6 +128,12 @@ func TestEl(t *testing.T) {
 		assert.Equal(t, `<div class="hat"><br></div>`, e)
 	})
 
+	t.Run("merges MergedAttr attributes with the same name", func(t *testing.T) {
+		e := g.El("div", controller("hat"), g.Attr("id", "party"),
+			g.Group([]g.Node{controller("<cake>")}), g.Attr("class", "fun"), g.Attr("data-controller"))
+		assert.Equal(t, `<div id="party" data-controller class="fun" data-controller="hat &lt;cake&gt;"></div>`, e)
+	})
+
 	t.Run("does not fail on nil node", func(t *testing.T) {
 		e := g.El("div", nil, g.El("br"), nil, g.El("br"))
 		assert.Equal(t, `<div><br><br></div>`, e)
@@ -299,6 +305,120 @@ func TestFragment(t *testing.T) {
 	})
 }
 
+func TestSelectByID(t *testing.T) {
+	page := Doctype(HTML(Body(
+		Div(ID("sidebar"), P(Text("Sidebar"))),
+		g.Group([]g.Node{
+			g.Fragment(Div(g.Group([]g.Node{ID("content")}), Class("main"), Text("Content"))),
+		}),
+	)))
+
+	t.Run("returns the element with the given id", func(t *testing.T) {
+		n, ok := g.SelectByID(page, "sidebar")
+		if !ok {
+			t.FailNow()
+		}
+		assert.Equal(t, `<div id="sidebar"><p>Sidebar</p></div>`, n)
+	})
+
+	t.Run("searches through groups and fragments", func(t *testing.T) {
+		n, ok := g.SelectByID(page, "content")
+		if !ok {
+			t.FailNow()
+		}
+		assert.Equal(t, `<div id="content" class="main">Content</div>`, n)
+	})
+
+	t.Run("returns false if no element has the id", func(t *testing.T) {
+		n, ok := g.SelectByID(page, "footer")
+		if ok || n != nil {
+			t.FailNow()
+		}
+	})
+
+	t.Run("does not render the rest of the tree", func(t *testing.T) {
+		n := Div(panickingNode{}, Span(ID("hat")))
+		if _, ok := g.SelectByID(n, "hat"); !ok {
+			t.FailNow()
+		}
+	})
+}
+
+func TestElementID(t *testing.T) {
+	t.Run("returns the id of an element", func(t *testing.T) {
+		id, ok := g.ElementID(Div(Class("hat"), ID("party")))
+		if !ok || id != "party" {
+			t.Fatal("id is", id)
+		}
+	})
+
+	t.Run("returns false for an element without id", func(t *testing.T) {
+		if _, ok := g.ElementID(Div()); ok {
+			t.FailNow()
+		}
+	})
+
+	t.Run("returns false for other nodes", func(t *testing.T) {
+		if _, ok := g.ElementID(g.Fragment(Div(ID("party")))); ok {
+			t.FailNow()
+		}
+	})
+}
+
+func TestAppendChildren(t *testing.T) {
+	t.Run("returns a copy of the element with the children added", func(t *testing.T) {
+		e := Div(ID("party"), Span())
+		n, ok := g.AppendChildren(e, Class("hat"), Br())
+		if !ok {
+			t.FailNow()
+		}
+		assert.Equal(t, `<div id="party" class="hat"><span></span><br></div>`, n)
+		assert.Equal(t, `<div id="party"><span></span></div>`, e)
+	})
+
+	t.Run("returns false for other nodes", func(t *testing.T) {
+		n := Text("party")
+		if _, ok := g.AppendChildren(n, Class("hat")); ok {
+			t.FailNow()
+		}
+	})
+}
+
+func TestElementContent(t *testing.T) {
+	t.Run("renders the children of the element without its tags and attributes", func(t *testing.T) {
+		n, ok := g.ElementContent(Div(ID("party"), Span(), g.Group([]g.Node{Class("hat"), Text("hats")})))
+		if !ok {
+			t.FailNow()
+		}
+		assert.Equal(t, `<span></span>hats`, n)
+	})
+
+	t.Run("returns false for other nodes", func(t *testing.T) {
+		if _, ok := g.ElementContent(Text("party")); ok {
+			t.FailNow()
+		}
+	})
+}
+
+// controller is a MergedAttr for the data-controller attribute, which has space-separated names.
+type controller string
+
+func (c controller) Render(w io.Writer) error {
+	_, err := io.WriteString(w, ` data-controller="`+string(c)+`"`)
+	return err
+}
+
+func (controller) Type() g.NodeType             { return g.AttributeType }
+func (controller) AttrName() string             { return "data-controller" }
+func (c controller) AttrValue() string          { return string(c) }
+func (controller) Merge(values []string) string { return strings.Join(values, " ") }
+
+type panickingNode struct{}
+
+func (panickingNode) Render(io.Writer) error {
+	panic("should not render")
+}
+
 func example() g.Node {
 	return Div(
 		g.Attr("class", "foo"),
@@ -4,19 +4,12 @@
 package html
 
 import (
-	"io"
-
 	g "github.com/alarbada/gomponents"
 )
 
 // Doctype returns a special kind of Node that prefixes its sibling with the string "<!doctype html>".
 func Doctype(sibling g.Node) g.Node {
-	return g.NodeFunc(func(w io.Writer) error {
-		if _, err := w.Write([]byte("<!doctype html>")); err != nil {
-			return err
-		}
-		return sibling.Render(w)
-	})
+	return g.Fragment(Raw("<!doctype html>"), sibling)
 }
 
 func A(children ...g.Node) g.Node          { return g.El("a", children...) }
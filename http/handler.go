@@ -11,10 +11,24 @@ import (
 // See Adapt for how errors are translated to HTTP responses.
 type Handler = func(http.ResponseWriter, *http.Request) (g.Node, error)
 
+// Middleware wraps a Handler, for example to transform the Node it returns.
+type Middleware = func(Handler) Handler
+
 type errorWithStatusCode interface {
 	StatusCode() int
 }
 
+// statusError is an error that is translated to its HTTP status code by Adapt.
+type statusError int
+
+func (e statusError) Error() string {
+	return http.StatusText(int(e))
+}
+
+func (e statusError) StatusCode() int {
+	return int(e)
+}
+
 // Adapt a Handler to a http.Handlerfunc.
 // The returned Node is rendered to the ResponseWriter, in both normal and error cases.
 // If the Handler returns an error, and it implements a "StatusCode() int" method, that HTTP status code is sent
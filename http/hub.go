@@ -0,0 +1,198 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	g "github.com/alarbada/gomponents"
+)
+
+// Hub broadcasts Nodes to WebSocket clients grouped by topic, for example to push out-of-band swaps
+// (see hx.SwapOob) to pages connected with the htmx ws extension.
+// Create a Hub with NewHub.
+type Hub struct {
+	// CheckOrigin decides whether to accept a connection request.
+	// If nil, only requests without an Origin header, or with an Origin matching the request host, are accepted.
+	CheckOrigin func(r *http.Request) bool
+
+	// OnMessage, if set, is called with every data message received from a client, such as the form values
+	// sent as JSON by the ws-send attribute. It is called from the goroutine serving that client.
+	OnMessage func(r *http.Request, topic string, message []byte)
+
+	mu     sync.Mutex
+	topics map[string]map[*client]struct{}
+}
+
+// client is a connection subscribed to a topic.
+// Frames sent on the channel are written to the connection. The Hub closes the channel when removing the client.
+type client struct {
+	send chan frame
+}
+
+// clientBuffer is how many frames can be queued for a client before it's considered too slow and is dropped.
+const clientBuffer = 16
+
+// writeTimeout for writing a single frame to a client.
+const writeTimeout = 10 * time.Second
+
+// NewHub creates a Hub without any clients.
+func NewHub() *Hub {
+	return &Hub{topics: map[string]map[*client]struct{}{}}
+}
+
+// Handler returns a http.Handler that upgrades requests to WebSocket connections,
+// and subscribes them to the topic returned by the topic function until they disconnect.
+// Requests that are not WebSocket handshakes get a 400 Bad Request, and rejected origins a 403 Forbidden.
+func (h *Hub) Handler(topic func(r *http.Request) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, err := checkHandshake(r)
+		if err != nil {
+			w.Header().Set("Sec-WebSocket-Version", "13")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		checkOrigin := h.CheckOrigin
+		if checkOrigin == nil {
+			checkOrigin = sameOrigin
+		}
+		if !checkOrigin(r) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		conn, rw, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		t := topic(r)
+		c := &client{send: make(chan frame, clientBuffer)}
+		h.subscribe(t, c)
+		defer h.remove(t, c)
+
+		if err := writeHandshake(rw.Writer, key); err != nil {
+			return
+		}
+
+		written := make(chan struct{})
+		go func() {
+			defer close(written)
+			for f := range c.send {
+				_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+				if err := writeFrame(rw.Writer, f); err != nil || f.opcode == opClose {
+					_ = conn.Close()
+					// Keep draining, so the Hub never blocks on this client.
+					for range c.send {
+					}
+					return
+				}
+			}
+			_ = conn.Close()
+		}()
+		defer func() {
+			h.remove(t, c)
+			<-written
+		}()
+
+		for {
+			m, err := readMessage(rw.Reader)
+			if err != nil {
+				return
+			}
+
+			switch m.opcode {
+			case opPing:
+				h.sendTo(t, c, frame{opcode: opPong, payload: m.payload})
+			case opPong:
+			case opClose:
+				var payload []byte
+				if len(m.payload) >= 2 {
+					payload = m.payload[:2]
+				}
+				h.sendTo(t, c, frame{opcode: opClose, payload: payload})
+				return
+			default:
+				if h.OnMessage != nil {
+					h.OnMessage(r, t, m.payload)
+				}
+			}
+		}
+	})
+}
+
+// Broadcast renders n once and sends it as a text message to all clients subscribed to topic.
+// Clients that can't keep up with the messages sent to them are disconnected.
+// The only error returned is from rendering n, in which case nothing is sent.
+func (h *Hub) Broadcast(topic string, n g.Node) error {
+	var b strings.Builder
+	if err := n.Render(&b); err != nil {
+		return err
+	}
+	f := frame{opcode: opText, payload: []byte(b.String())}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for c := range h.topics[topic] {
+		h.sendLocked(topic, c, f)
+	}
+	return nil
+}
+
+func (h *Hub) subscribe(topic string, c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.topics[topic] == nil {
+		h.topics[topic] = map[*client]struct{}{}
+	}
+	h.topics[topic][c] = struct{}{}
+}
+
+func (h *Hub) sendTo(topic string, c *client, f frame) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.sendLocked(topic, c, f)
+}
+
+// sendLocked queues f for c, or removes c if its queue is full. h.mu must be held.
+func (h *Hub) sendLocked(topic string, c *client, f frame) {
+	if _, ok := h.topics[topic][c]; !ok {
+		return
+	}
+
+	select {
+	case c.send <- f:
+	default:
+		h.removeLocked(topic, c)
+	}
+}
+
+func (h *Hub) remove(topic string, c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.removeLocked(topic, c)
+}
+
+// removeLocked unsubscribes c and closes its channel, if it's still subscribed. h.mu must be held.
+func (h *Hub) removeLocked(topic string, c *client) {
+	clients := h.topics[topic]
+	if _, ok := clients[c]; !ok {
+		return
+	}
+
+	delete(clients, c)
+	close(c.send)
+	if len(clients) == 0 {
+		delete(h.topics, topic)
+	}
+}
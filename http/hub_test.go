@@ -0,0 +1,193 @@
+package http_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	ghttp "github.com/alarbada/gomponents/http"
+)
+
+func TestHub(t *testing.T) {
+	t.Run("broadcasts rendered nodes to clients subscribed to the topic", func(t *testing.T) {
+		hub := ghttp.NewHub()
+		server := httptest.NewServer(hub.Handler(func(r *http.Request) string {
+			return r.URL.Query().Get("topic")
+		}))
+		defer server.Close()
+
+		todos1 := dialWebSocket(t, server, "/?topic=todos")
+		todos2 := dialWebSocket(t, server, "/?topic=todos")
+		other := dialWebSocket(t, server, "/?topic=other")
+
+		if err := hub.Broadcast("todos", g.El("div", g.Attr("id", "count"), g.Attr("hx-swap-oob", "true"))); err != nil {
+			t.Fatal(err)
+		}
+		if err := hub.Broadcast("other", g.El("span")); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, c := range []*wsClient{todos1, todos2} {
+			opcode, payload := c.read(t)
+			if opcode != 0x1 || payload != `<div id="count" hx-swap-oob="true"></div>` {
+				t.Fatal("message is", opcode, payload)
+			}
+		}
+		if _, payload := other.read(t); payload != "<span></span>" {
+			t.Fatal("message is", payload)
+		}
+	})
+
+	t.Run("passes client messages to OnMessage", func(t *testing.T) {
+		hub := ghttp.NewHub()
+		messages := make(chan string, 1)
+		hub.OnMessage = func(r *http.Request, topic string, message []byte) {
+			messages <- topic + ": " + string(message)
+		}
+		server := httptest.NewServer(hub.Handler(func(r *http.Request) string { return "chat" }))
+		defer server.Close()
+
+		c := dialWebSocket(t, server, "/")
+		c.write(t, 0x1, `{"message":"hi"}`)
+		if m := <-messages; m != `chat: {"message":"hi"}` {
+			t.Fatal("message is", m)
+		}
+	})
+
+	t.Run("answers pings and close frames", func(t *testing.T) {
+		hub := ghttp.NewHub()
+		server := httptest.NewServer(hub.Handler(func(r *http.Request) string { return "" }))
+		defer server.Close()
+
+		c := dialWebSocket(t, server, "/")
+		c.write(t, 0x9, "hat")
+		if opcode, payload := c.read(t); opcode != 0xa || payload != "hat" {
+			t.Fatal("message is", opcode, payload)
+		}
+
+		c.write(t, 0x8, "\x03\xe8")
+		if opcode, payload := c.read(t); opcode != 0x8 || payload != "\x03\xe8" {
+			t.Fatal("message is", opcode, payload)
+		}
+		if _, err := c.r.ReadByte(); err != io.EOF {
+			t.Fatal("connection not closed:", err)
+		}
+	})
+
+	t.Run("rejects requests that are not websocket handshakes", func(t *testing.T) {
+		hub := ghttp.NewHub()
+		code, _ := get(t, hub.Handler(func(r *http.Request) string { return "" }))
+		if code != http.StatusBadRequest {
+			t.Fatal("status code is", code)
+		}
+	})
+
+	t.Run("rejects cross-origin requests by default", func(t *testing.T) {
+		hub := ghttp.NewHub()
+		server := httptest.NewServer(hub.Handler(func(r *http.Request) string { return "" }))
+		defer server.Close()
+
+		conn, err := net.Dial("tcp", server.Listener.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+		resp, _ := handshake(t, conn, server.Listener.Addr().String(), "/", "http://example.com")
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatal("status code is", resp.StatusCode)
+		}
+	})
+}
+
+type wsClient struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func dialWebSocket(t *testing.T, server *httptest.Server, path string) *wsClient {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	resp, r := handshake(t, conn, server.Listener.Addr().String(), path, server.URL)
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatal("status code is", resp.StatusCode)
+	}
+	// The accept key for the sample key in RFC 6455, section 1.3.
+	if accept := resp.Header.Get("Sec-WebSocket-Accept"); accept != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatal("accept key is", accept)
+	}
+	return &wsClient{conn: conn, r: r}
+}
+
+func handshake(t *testing.T, conn net.Conn, host, path, origin string) (*http.Response, *bufio.Reader) {
+	t.Helper()
+
+	request := "GET " + path + " HTTP/1.1\r\n" +
+		"Host: " + host + "\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Origin: " + origin + "\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n" +
+		"Sec-WebSocket-Version: 13\r\n\r\n"
+	if _, err := conn.Write([]byte(request)); err != nil {
+		t.Fatal(err)
+	}
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, r
+}
+
+// write a masked frame, as clients must.
+func (c *wsClient) write(t *testing.T, opcode byte, payload string) {
+	t.Helper()
+
+	mask := []byte{1, 2, 3, 4}
+	b := []byte{0x80 | opcode, 0x80 | byte(len(payload))}
+	b = append(b, mask...)
+	for i := range payload {
+		b = append(b, payload[i]^mask[i%4])
+	}
+	if _, err := c.conn.Write(b); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// read an unmasked frame, as servers send.
+func (c *wsClient) read(t *testing.T) (byte, string) {
+	t.Helper()
+
+	var header [2]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		t.Fatal(err)
+	}
+	length := int(header[1] & 0x7f)
+	if length == 126 {
+		var b [2]byte
+		if _, err := io.ReadFull(c.r, b[:]); err != nil {
+			t.Fatal(err)
+		}
+		length = int(binary.BigEndian.Uint16(b[:]))
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		t.Fatal(err)
+	}
+	return header[0] & 0x0f, string(payload)
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"net/http"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx"
+)
+
+// Layout returns a Middleware that wraps the Node returned by a Handler with the given layout function,
+// for example to put it inside a full HTML5 document.
+//
+// The layout is skipped for htmx requests (with header "HX-Request: true"), because htmx only swaps in a
+// fragment of the page. Boosted requests ("HX-Boosted: true") and history restore requests
+// ("HX-History-Restore-Request: true") need the full page, so the layout is applied to those.
+// If the Handler returns a nil Node, the layout is skipped as well.
+// The response varies on all three headers, so caches don't serve a fragment where the full page is needed.
+func Layout(layout func(r *http.Request, body g.Node) g.Node) Middleware {
+	return func(h Handler) Handler {
+		return func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+			w.Header().Add("Vary", "HX-Request")
+			w.Header().Add("Vary", "HX-Boosted")
+			w.Header().Add("Vary", "HX-History-Restore-Request")
+
+			n, err := h(w, r)
+			if n == nil || hx.Request(r).Partial() {
+				return n, err
+			}
+			return layout(r, n), err
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package http_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	ghttp "github.com/alarbada/gomponents/http"
+)
+
+func TestLayout(t *testing.T) {
+	layout := ghttp.Layout(func(r *http.Request, body g.Node) g.Node {
+		return g.El("main", body)
+	})
+	h := ghttp.Adapt(layout(func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+		return g.El("div"), nil
+	}))
+
+	t.Run("wraps the node in the layout", func(t *testing.T) {
+		_, body := getWithHeader(t, h, nil)
+		if body != "<main><div></div></main>" {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("skips the layout for htmx requests", func(t *testing.T) {
+		_, body := getWithHeader(t, h, http.Header{"Hx-Request": {"true"}})
+		if body != "<div></div>" {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("applies the layout for boosted htmx requests", func(t *testing.T) {
+		_, body := getWithHeader(t, h, http.Header{"Hx-Request": {"true"}, "Hx-Boosted": {"true"}})
+		if body != "<main><div></div></main>" {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("applies the layout for htmx history restore requests", func(t *testing.T) {
+		_, body := getWithHeader(t, h, http.Header{"Hx-Request": {"true"}, "Hx-History-Restore-Request": {"true"}})
+		if body != "<main><div></div></main>" {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("skips the layout when returning nil node", func(t *testing.T) {
+		h := ghttp.Adapt(layout(func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+			return nil, nil
+		}))
+		_, body := getWithHeader(t, h, nil)
+		if body != "" {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("varies the response on the htmx request headers", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		if v := strings.Join(recorder.Header().Values("Vary"), ", "); v != "HX-Request, HX-Boosted, HX-History-Restore-Request" {
+			t.Fatal("vary is", v)
+		}
+	})
+}
+
+func getWithHeader(t *testing.T, h http.Handler, header http.Header) (int, string) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header = header
+	if request.Header == nil {
+		request.Header = http.Header{}
+	}
+	h.ServeHTTP(recorder, request)
+	result := recorder.Result()
+	body, err := io.ReadAll(result.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result.StatusCode, string(body)
+}
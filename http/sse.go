@@ -0,0 +1,107 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	g "github.com/alarbada/gomponents"
+)
+
+// Event is a Server-Sent Event, with a Name and a Node that is rendered as its data.
+// An empty Name sends the event as the default "message" event.
+type Event struct {
+	Name string
+	Node g.Node
+}
+
+// SSE streams events to the client as Server-Sent Events, for example for the htmx sse extension.
+// Each Node is rendered and split into one "data:" field per line, and the response is flushed after every event.
+// If heartbeat is positive, a comment is sent whenever no event has been sent for that duration,
+// to keep the connection open through proxies.
+// SSE returns nil when the events channel is closed, and the context error when the request context is done.
+// Write and render errors are returned as well, after which the stream should be considered broken.
+func SSE(w http.ResponseWriter, r *http.Request, events <-chan Event, heartbeat time.Duration) error {
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return err
+	}
+
+	var ticker *time.Ticker
+	var tick <-chan time.Time
+	if heartbeat > 0 {
+		ticker = time.NewTicker(heartbeat)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return r.Context().Err()
+
+		case <-tick:
+			if _, err := w.Write([]byte(": heartbeat\n\n")); err != nil {
+				return err
+			}
+			if err := rc.Flush(); err != nil {
+				return err
+			}
+
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := writeEvent(w, e); err != nil {
+				return err
+			}
+			if err := rc.Flush(); err != nil {
+				return err
+			}
+			if ticker != nil {
+				ticker.Reset(heartbeat)
+			}
+		}
+	}
+}
+
+var errInvalidEventName = errors.New("event name must not contain line breaks")
+
+// writeEvent in the text/event-stream format.
+// See https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation
+func writeEvent(w http.ResponseWriter, e Event) error {
+	if strings.ContainsAny(e.Name, "\r\n") {
+		return errInvalidEventName
+	}
+
+	var data strings.Builder
+	if e.Node != nil {
+		if err := e.Node.Render(&data); err != nil {
+			return err
+		}
+	}
+
+	var b strings.Builder
+	if e.Name != "" {
+		b.WriteString("event: ")
+		b.WriteString(e.Name)
+		b.WriteString("\n")
+	}
+
+	lines := strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(data.String())
+	for _, line := range strings.Split(lines, "\n") {
+		b.WriteString("data: ")
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	_, err := w.Write([]byte(b.String()))
+	return err
+}
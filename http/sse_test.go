@@ -0,0 +1,98 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	g "github.com/alarbada/gomponents"
+	ghttp "github.com/alarbada/gomponents/http"
+)
+
+func TestSSE(t *testing.T) {
+	t.Run("streams events until the channel is closed", func(t *testing.T) {
+		events := make(chan ghttp.Event, 2)
+		events <- ghttp.Event{Name: "update", Node: g.El("div", g.Attr("id", "a"))}
+		events <- ghttp.Event{Node: g.El("pre", g.El("span"), g.Fragment(rawNode("\n"), g.El("span")))}
+		close(events)
+
+		recorder := httptest.NewRecorder()
+		err := ghttp.SSE(recorder, httptest.NewRequest(http.MethodGet, "/", nil), events, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if ct := recorder.Header().Get("Content-Type"); ct != "text/event-stream" {
+			t.Fatal("content type is", ct)
+		}
+		expected := "event: update\ndata: <div id=\"a\"></div>\n\n" +
+			"data: <pre><span></span>\ndata: <span></span></pre>\n\n"
+		if body := recorder.Body.String(); body != expected {
+			t.Fatal("body is", body)
+		}
+		if !recorder.Flushed {
+			t.Fatal("not flushed")
+		}
+	})
+
+	t.Run("sends heartbeats and stops when the request context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+
+		done := make(chan error)
+		go func() {
+			done <- ghttp.SSE(recorder, request, make(chan ghttp.Event), time.Millisecond)
+		}()
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Fatal("error is", err)
+		}
+		if body := recorder.Body.String(); !strings.HasPrefix(body, ": heartbeat\n\n") {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("sends no heartbeats while events are sent", func(t *testing.T) {
+		events := make(chan ghttp.Event)
+		go func() {
+			for i := 0; i < 30; i++ {
+				time.Sleep(10 * time.Millisecond)
+				events <- ghttp.Event{Node: rawNode("<p></p>")}
+			}
+			close(events)
+		}()
+
+		recorder := httptest.NewRecorder()
+		if err := ghttp.SSE(recorder, httptest.NewRequest(http.MethodGet, "/", nil), events, 200*time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+		if body := recorder.Body.String(); strings.Contains(body, "heartbeat") {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("errors on event names with line breaks", func(t *testing.T) {
+		events := make(chan ghttp.Event, 1)
+		events <- ghttp.Event{Name: "update\ndata: oops"}
+
+		err := ghttp.SSE(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), events, 0)
+		if err == nil {
+			t.Fatal("error is nil")
+		}
+	})
+}
+
+type rawNode string
+
+func (n rawNode) Render(w io.Writer) error {
+	_, err := w.Write([]byte(n))
+	return err
+}
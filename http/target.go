@@ -0,0 +1,42 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx"
+)
+
+// SelectTarget is a Middleware that responds to partial htmx requests with only the element targeted by the
+// request, so one Handler can serve both the full page and its fragments.
+// The target is the element in the returned Node with an id matching the "HX-Target" request header,
+// found with g.SelectByID. The rest of the page is never rendered.
+// If no element has that id, the Handler's Node is dropped and a 404 Not Found error is returned instead.
+// Requests without a target, and boosted or history restore requests, get the full Node as usual.
+//
+// htmx swaps the response into the target by default (hx-swap="innerHTML"), so only the content of the target
+// element is rendered, to not nest it in itself. For targets swapped with hx-swap="outerHTML", set the
+// "HX-Reswap: outerHTML" response header in the Handler, for example with hx.SetReswap,
+// and the target element itself is rendered.
+func SelectTarget(h Handler) Handler {
+	return func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+		w.Header().Add("Vary", "HX-Target")
+
+		n, err := h(w, r)
+		info := hx.Request(r)
+		if n == nil || err != nil || info.Target == "" || !info.Partial() {
+			return n, err
+		}
+
+		selected, ok := g.SelectByID(n, info.Target)
+		if !ok {
+			return nil, statusError(http.StatusNotFound)
+		}
+		if strings.HasPrefix(w.Header().Get("HX-Reswap"), "outerHTML") {
+			return selected, nil
+		}
+		content, _ := g.ElementContent(selected)
+		return content, nil
+	}
+}
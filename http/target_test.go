@@ -0,0 +1,65 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	ghttp "github.com/alarbada/gomponents/http"
+	"github.com/alarbada/gomponents/hx"
+)
+
+func TestSelectTarget(t *testing.T) {
+	h := ghttp.Adapt(ghttp.SelectTarget(func(w http.ResponseWriter, r *http.Request) (g.Node, error) {
+		if r.URL.Query().Has("outer") {
+			hx.SetReswap(w, "outerHTML")
+		}
+		return g.El("body", g.El("div", g.Attr("id", "content"), g.El("p"))), nil
+	}))
+
+	t.Run("renders the whole node for normal requests", func(t *testing.T) {
+		_, body := getWithHeader(t, h, http.Header{"Hx-Target": {"content"}})
+		if body != `<body><div id="content"><p></p></div></body>` {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("renders only the content of the target for htmx requests", func(t *testing.T) {
+		code, body := getWithHeader(t, h, http.Header{"Hx-Request": {"true"}, "Hx-Target": {"content"}})
+		if code != http.StatusOK {
+			t.Fatal("status code is", code)
+		}
+		if body != `<p></p>` {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("renders the target element for outerHTML swaps", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/?outer", nil)
+		request.Header.Set("HX-Request", "true")
+		request.Header.Set("HX-Target", "content")
+		h.ServeHTTP(recorder, request)
+		if body := recorder.Body.String(); body != `<div id="content"><p></p></div>` {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("renders the whole node for htmx requests without a target", func(t *testing.T) {
+		_, body := getWithHeader(t, h, http.Header{"Hx-Request": {"true"}})
+		if body != `<body><div id="content"><p></p></div></body>` {
+			t.Fatal("body is", body)
+		}
+	})
+
+	t.Run("errors with 404 if the target is not found", func(t *testing.T) {
+		code, body := getWithHeader(t, h, http.Header{"Hx-Request": {"true"}, "Hx-Target": {"sidebar"}})
+		if code != http.StatusNotFound {
+			t.Fatal("status code is", code)
+		}
+		if body != "" {
+			t.Fatal("body is", body)
+		}
+	})
+}
@@ -0,0 +1,207 @@
+package http
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// This file implements the small part of the WebSocket protocol needed by Hub.
+// See https://www.rfc-editor.org/rfc/rfc6455
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// maxMessageSize is the largest message accepted from a client.
+const maxMessageSize = 1 << 20
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+var (
+	errNotWebSocket    = errors.New("not a websocket handshake")
+	errUnmaskedFrame   = errors.New("websocket: client frame is not masked")
+	errMessageTooLarge = errors.New("websocket: message too large")
+	errBadFrame        = errors.New("websocket: invalid frame")
+)
+
+// frame is a single WebSocket frame, or a whole message assembled from frames.
+type frame struct {
+	opcode  byte
+	payload []byte
+}
+
+// acceptKey for the Sec-WebSocket-Accept response header.
+func acceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// checkHandshake returns the Sec-WebSocket-Key of a valid WebSocket opening handshake request.
+func checkHandshake(r *http.Request) (string, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet ||
+		!headerContainsToken(r.Header, "Connection", "upgrade") ||
+		!headerContainsToken(r.Header, "Upgrade", "websocket") ||
+		r.Header.Get("Sec-WebSocket-Version") != "13" ||
+		key == "" {
+		return "", errNotWebSocket
+	}
+	return key, nil
+}
+
+func headerContainsToken(h http.Header, name, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sameOrigin reports whether the Origin request header, if any, matches the request host.
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// writeHandshake response to a valid handshake request with the given key.
+func writeHandshake(w *bufio.Writer, key string) error {
+	_, _ = w.WriteString("HTTP/1.1 101 Switching Protocols\r\n")
+	_, _ = w.WriteString("Upgrade: websocket\r\n")
+	_, _ = w.WriteString("Connection: Upgrade\r\n")
+	_, _ = w.WriteString("Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n")
+	return w.Flush()
+}
+
+// readMessage from a client, assembling fragmented messages.
+// Control frames are returned as they arrive, even in between the fragments of a message.
+func readMessage(r *bufio.Reader) (frame, error) {
+	var message frame
+	for {
+		fin, f, err := readFrame(r)
+		if err != nil {
+			return frame{}, err
+		}
+
+		switch {
+		case f.opcode >= opClose:
+			if !fin || len(f.payload) > 125 {
+				return frame{}, errBadFrame
+			}
+			return f, nil
+		case f.opcode == opContinuation:
+			if message.opcode == 0 {
+				return frame{}, errBadFrame
+			}
+		default:
+			if message.opcode != 0 {
+				return frame{}, errBadFrame
+			}
+			message.opcode = f.opcode
+		}
+
+		if len(message.payload)+len(f.payload) > maxMessageSize {
+			return frame{}, errMessageTooLarge
+		}
+		message.payload = append(message.payload, f.payload...)
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+// readFrame from a client. Client frames must be masked.
+func readFrame(r *bufio.Reader) (bool, frame, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return false, frame{}, err
+	}
+
+	fin := header[0]&0x80 != 0
+	opcode := header[0] & 0x0f
+	if header[0]&0x70 != 0 {
+		return false, frame{}, errBadFrame
+	}
+	if header[1]&0x80 == 0 {
+		return false, frame{}, errUnmaskedFrame
+	}
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var b [2]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return false, frame{}, err
+		}
+		length = uint64(binary.BigEndian.Uint16(b[:]))
+	case 127:
+		var b [8]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return false, frame{}, err
+		}
+		length = binary.BigEndian.Uint64(b[:])
+	}
+	if length > maxMessageSize {
+		return false, frame{}, errMessageTooLarge
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(r, mask[:]); err != nil {
+		return false, frame{}, err
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return false, frame{}, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return fin, frame{opcode: opcode, payload: payload}, nil
+}
+
+// writeFrame as a single, unmasked, final frame, as sent by servers.
+func writeFrame(w *bufio.Writer, f frame) error {
+	_ = w.WriteByte(0x80 | f.opcode)
+
+	switch l := len(f.payload); {
+	case l <= 125:
+		_ = w.WriteByte(byte(l))
+	case l <= 0xffff:
+		_ = w.WriteByte(126)
+		var b [2]byte
+		binary.BigEndian.PutUint16(b[:], uint16(l))
+		_, _ = w.Write(b[:])
+	default:
+		_ = w.WriteByte(127)
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], uint64(l))
+		_, _ = w.Write(b[:])
+	}
+
+	_, _ = w.Write(f.payload)
+	return w.Flush()
+}
@@ -0,0 +1,60 @@
+package hx
+
+import (
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+
+	g "github.com/alarbada/gomponents"
+)
+
+// Ext returns an hx-ext attribute, which enables htmx extensions by name.
+// Several Ext attributes on the same element are rendered as one hx-ext attribute, with the extension names
+// joined by commas and without duplicates, so that several helpers can each enable the extension they need.
+func Ext(ext string) g.Node {
+	return extAttr(ext)
+}
+
+type extAttr string
+
+// Render satisfies g.Node.
+func (e extAttr) Render(w io.Writer) error {
+	_, err := io.WriteString(w, ` hx-ext="`+template.HTMLEscapeString(string(e))+`"`)
+	return err
+}
+
+func (extAttr) Type() g.NodeType {
+	return g.AttributeType
+}
+
+// String satisfies fmt.Stringer.
+func (e extAttr) String() string {
+	var b strings.Builder
+	_ = e.Render(&b)
+	return b.String()
+}
+
+// AttrName satisfies g.MergedAttr.
+func (extAttr) AttrName() string {
+	return "hx-ext"
+}
+
+// AttrValue satisfies g.MergedAttr.
+func (e extAttr) AttrValue() string {
+	return string(e)
+}
+
+// Merge satisfies g.MergedAttr.
+func (extAttr) Merge(values []string) string {
+	var exts []string
+	for _, value := range values {
+		for _, ext := range strings.Split(value, ",") {
+			ext = strings.TrimSpace(ext)
+			if ext != "" && !slices.Contains(exts, ext) {
+				exts = append(exts, ext)
+			}
+		}
+	}
+	return strings.Join(exts, ",")
+}
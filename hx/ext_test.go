@@ -0,0 +1,20 @@
+package hx_test
+
+import (
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx"
+	"github.com/alarbada/gomponents/internal/assert"
+)
+
+func TestExt(t *testing.T) {
+	t.Run("merges hx-ext attributes without duplicates", func(t *testing.T) {
+		e := g.El("div", hx.Ext("sse"), g.Attr("id", "hat"), g.Group([]g.Node{hx.Ext("preload, sse")}))
+		assert.Equal(t, `<div id="hat" hx-ext="sse,preload"></div>`, e)
+	})
+
+	t.Run("renders on its own", func(t *testing.T) {
+		assert.Equal(t, ` hx-ext="sse"`, hx.Ext("sse"))
+	})
+}
@@ -5,21 +5,42 @@ import (
 	g "github.com/alarbada/gomponents"
 )
 
-func Boost() g.Node { return g.Attr("hx-boost", "true") }
-
-func Get(path string) g.Node    { return g.Attr("hx-get", path) }
-func Post(path string) g.Node   { return g.Attr("hx-post", path) }
-func Put(path string) g.Node    { return g.Attr("hx-put", path) }
-func Delete(path string) g.Node { return g.Attr("hx-delete", path) }
+// This file has an attribute helper for every htmx attribute.
+// See https://htmx.org/reference/#attributes
 
+func Boost() g.Node                  { return g.Attr("hx-boost", "true") }
+func Confirm(v string) g.Node        { return g.Attr("hx-confirm", v) }
+func Delete(path string) g.Node      { return g.Attr("hx-delete", path) }
+func Disable() g.Node                { return g.Attr("hx-disable") }
+func DisabledElt(v string) g.Node    { return g.Attr("hx-disabled-elt", v) }
+func Disinherit(v string) g.Node     { return g.Attr("hx-disinherit", v) }
+func Encoding(v string) g.Node       { return g.Attr("hx-encoding", v) }
+func Get(path string) g.Node         { return g.Attr("hx-get", path) }
+func Headers(v string) g.Node        { return g.Attr("hx-headers", v) }
+func History(v string) g.Node        { return g.Attr("hx-history", v) }
+func HistoryElt() g.Node             { return g.Attr("hx-history-elt") }
+func Include(v string) g.Node        { return g.Attr("hx-include", v) }
+func Indicator(v string) g.Node      { return g.Attr("hx-indicator", v) }
+func Inherit(v string) g.Node        { return g.Attr("hx-inherit", v) }
 func On(evt, code string) g.Node     { return g.Attr("hx-on:"+evt, code) }
+func OnHx(evt, code string) g.Node   { return g.Attr("hx-on::"+evt, code) }
+func Params(v string) g.Node         { return g.Attr("hx-params", v) }
+func Patch(path string) g.Node       { return g.Attr("hx-patch", path) }
+func Post(path string) g.Node        { return g.Attr("hx-post", path) }
+func Preserve() g.Node               { return g.Attr("hx-preserve") }
+func Prompt(v string) g.Node         { return g.Attr("hx-prompt", v) }
 func PushUrl(val string) g.Node      { return g.Attr("hx-push-url", val) }
 func PushUrlT() g.Node               { return g.Attr("hx-push-url", "true") }
+func Put(path string) g.Node         { return g.Attr("hx-put", path) }
+func ReplaceUrl(val string) g.Node   { return g.Attr("hx-replace-url", val) }
+func ReplaceUrlT() g.Node            { return g.Attr("hx-replace-url", "true") }
+func RequestAttr(v string) g.Node    { return g.Attr("hx-request", v) }
 func Select(target string) g.Node    { return g.Attr("hx-select", target) }
 func SelectOob(target string) g.Node { return g.Attr("hx-select-oob", target) }
 func Swap(how string) g.Node         { return g.Attr("hx-swap", how) }
 func SwapOob(how string) g.Node      { return g.Attr("hx-swap-oob", how) }
+func Sync(v string) g.Node           { return g.Attr("hx-sync", v) }
 func Target(target string) g.Node    { return g.Attr("hx-target", target) }
 func Trigger(trigger string) g.Node  { return g.Attr("hx-trigger", trigger) }
+func Validate() g.Node               { return g.Attr("hx-validate", "true") }
 func Vals(vals string) g.Node        { return g.Attr("hx-vals", vals) }
-func Ext(ext string) g.Node          { return g.Attr("hx-ext", ext) }
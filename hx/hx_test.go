@@ -0,0 +1,95 @@
+package hx_test
+
+import (
+	"fmt"
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx"
+	"github.com/alarbada/gomponents/internal/assert"
+)
+
+func TestBooleanAttributes(t *testing.T) {
+	cases := map[string]func() g.Node{
+		"hx-disable":     hx.Disable,
+		"hx-history-elt": hx.HistoryElt,
+		"hx-preserve":    hx.Preserve,
+	}
+
+	for name, fn := range cases {
+		t.Run(fmt.Sprintf("should output %v", name), func(t *testing.T) {
+			n := g.El("div", fn())
+			assert.Equal(t, fmt.Sprintf(`<div %v></div>`, name), n)
+		})
+	}
+}
+
+func TestTrueAttributes(t *testing.T) {
+	cases := map[string]func() g.Node{
+		"hx-boost":       hx.Boost,
+		"hx-push-url":    hx.PushUrlT,
+		"hx-replace-url": hx.ReplaceUrlT,
+		"hx-validate":    hx.Validate,
+	}
+
+	for name, fn := range cases {
+		t.Run(fmt.Sprintf(`should output %v="true"`, name), func(t *testing.T) {
+			n := g.El("div", fn())
+			assert.Equal(t, fmt.Sprintf(`<div %v="true"></div>`, name), n)
+		})
+	}
+}
+
+func TestSimpleAttributes(t *testing.T) {
+	cases := map[string]func(string) g.Node{
+		"hx-confirm":      hx.Confirm,
+		"hx-delete":       hx.Delete,
+		"hx-disabled-elt": hx.DisabledElt,
+		"hx-disinherit":   hx.Disinherit,
+		"hx-encoding":     hx.Encoding,
+		"hx-ext":          hx.Ext,
+		"hx-get":          hx.Get,
+		"hx-headers":      hx.Headers,
+		"hx-history":      hx.History,
+		"hx-include":      hx.Include,
+		"hx-indicator":    hx.Indicator,
+		"hx-inherit":      hx.Inherit,
+		"hx-params":       hx.Params,
+		"hx-patch":        hx.Patch,
+		"hx-post":         hx.Post,
+		"hx-prompt":       hx.Prompt,
+		"hx-push-url":     hx.PushUrl,
+		"hx-put":          hx.Put,
+		"hx-replace-url":  hx.ReplaceUrl,
+		"hx-request":      hx.RequestAttr,
+		"hx-select":       hx.Select,
+		"hx-select-oob":   hx.SelectOob,
+		"hx-swap":         hx.Swap,
+		"hx-swap-oob":     hx.SwapOob,
+		"hx-sync":         hx.Sync,
+		"hx-target":       hx.Target,
+		"hx-trigger":      hx.Trigger,
+		"hx-vals":         hx.Vals,
+	}
+
+	for name, fn := range cases {
+		t.Run(fmt.Sprintf(`should output %v="hat"`, name), func(t *testing.T) {
+			n := g.El("div", fn("hat"))
+			assert.Equal(t, fmt.Sprintf(`<div %v="hat"></div>`, name), n)
+		})
+	}
+}
+
+func TestOn(t *testing.T) {
+	t.Run("returns an attribute which name is prefixed with hx-on:", func(t *testing.T) {
+		n := hx.On("click", "alert('hat')")
+		assert.Equal(t, ` hx-on:click="alert(&#39;hat&#39;)"`, n)
+	})
+}
+
+func TestOnHx(t *testing.T) {
+	t.Run("returns an attribute which name is prefixed with hx-on::", func(t *testing.T) {
+		n := hx.OnHx("before-request", "alert('hat')")
+		assert.Equal(t, ` hx-on::before-request="alert(&#39;hat&#39;)"`, n)
+	})
+}
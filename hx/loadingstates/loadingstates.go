@@ -0,0 +1,36 @@
+// Package loadingstates provides attributes for the htmx loading-states extension,
+// which changes elements while a request is in flight.
+// States marks the scope of the loading states and enables the extension with an hx-ext attribute.
+// The other attributes must be used inside that scope.
+// See https://htmx.org/extensions/loading-states/
+package loadingstates
+
+import (
+	"strconv"
+	"time"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx"
+)
+
+func States() g.Node {
+	return g.Group([]g.Node{hx.Ext("loading-states"), g.Attr("data-loading-states")})
+}
+
+// Loading shows the element while loading, with display "inline-block", or display if given, like "flex".
+// More than one display makes Loading panic.
+func Loading(display ...string) g.Node {
+	return g.Attr("data-loading", display...)
+}
+
+func Class(classes string) g.Node       { return g.Attr("data-loading-class", classes) }
+func ClassRemove(classes string) g.Node { return g.Attr("data-loading-class-remove", classes) }
+func Disable() g.Node                   { return g.Attr("data-loading-disable") }
+func AriaBusy() g.Node                  { return g.Attr("data-loading-aria-busy") }
+func Target(selector string) g.Node     { return g.Attr("data-loading-target", selector) }
+func Path(path string) g.Node           { return g.Attr("data-loading-path", path) }
+
+// Delay the loading state by d, to avoid flickering on fast requests.
+func Delay(d time.Duration) g.Node {
+	return g.Attr("data-loading-delay", strconv.FormatInt(d.Milliseconds(), 10))
+}
@@ -0,0 +1,37 @@
+package loadingstates_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx/loadingstates"
+	"github.com/alarbada/gomponents/internal/assert"
+)
+
+func TestStates(t *testing.T) {
+	t.Run("marks the scope and enables the extension", func(t *testing.T) {
+		assert.Equal(t, `<form data-loading-states hx-ext="loading-states"></form>`, g.El("form", loadingstates.States()))
+	})
+}
+
+func TestAttributes(t *testing.T) {
+	cases := map[string]g.Node{
+		`data-loading`:                       loadingstates.Loading(),
+		`data-loading="flex"`:                loadingstates.Loading("flex"),
+		`data-loading-class="opacity-50"`:    loadingstates.Class("opacity-50"),
+		`data-loading-class-remove="hidden"`: loadingstates.ClassRemove("hidden"),
+		`data-loading-disable`:               loadingstates.Disable(),
+		`data-loading-aria-busy`:             loadingstates.AriaBusy(),
+		`data-loading-target="#spinner"`:     loadingstates.Target("#spinner"),
+		`data-loading-path="/save"`:          loadingstates.Path("/save"),
+		`data-loading-delay="1500"`:          loadingstates.Delay(1500 * time.Millisecond),
+	}
+
+	for expected, n := range cases {
+		t.Run(fmt.Sprintf("should output %v", expected), func(t *testing.T) {
+			assert.Equal(t, fmt.Sprintf(`<div %v></div>`, expected), g.El("div", n))
+		})
+	}
+}
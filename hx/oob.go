@@ -0,0 +1,82 @@
+package hx
+
+import (
+	"errors"
+	"io"
+
+	g "github.com/alarbada/gomponents"
+)
+
+// OOB is an out-of-band swap for Response.
+// See https://htmx.org/attributes/hx-swap-oob/
+type OOB struct {
+	// Node to swap in. It must be an element created with El or one of its helpers.
+	Node g.Node
+	// Strategy is how to swap, like "innerHTML" or "beforeend". It defaults to "outerHTML".
+	Strategy string
+	// Selector for the element to swap. If empty, the element with the same id as Node is swapped,
+	// so Node must have an id.
+	Selector string
+}
+
+var (
+	errOOBNotElement = errors.New("out-of-band swap node must be an element")
+	errOOBNoTarget   = errors.New("out-of-band swap node must have an id or a selector")
+)
+
+// Response combines a primary Node, swapped into the target as usual, with out-of-band swaps of other elements,
+// for example to update a counter badge and show a toast along with the main content.
+// An hx-swap-oob attribute is added to each OOB Node, and they are rendered after the primary Node.
+// If an OOB is invalid, rendering the returned Node fails without writing anything.
+func Response(primary g.Node, oob ...OOB) g.Node {
+	nodes := make([]g.Node, 0, len(oob)+1)
+	nodes = append(nodes, primary)
+
+	var err error
+	for _, o := range oob {
+		n, oobErr := o.node()
+		if oobErr != nil {
+			err = oobErr
+			break
+		}
+		nodes = append(nodes, n)
+	}
+
+	return g.NodeFunc(func(w io.Writer) error {
+		if err != nil {
+			return err
+		}
+		for _, n := range nodes {
+			if n == nil {
+				continue
+			}
+			if err := n.Render(w); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// node returns the OOB Node with the hx-swap-oob attribute added.
+func (o OOB) node() (g.Node, error) {
+	value := o.Strategy
+	switch {
+	case o.Selector != "":
+		if value == "" {
+			value = "outerHTML"
+		}
+		value += ":" + o.Selector
+	case value == "":
+		value = "true"
+	}
+
+	n, ok := g.AppendChildren(o.Node, SwapOob(value))
+	if !ok {
+		return nil, errOOBNotElement
+	}
+	if _, hasID := g.ElementID(o.Node); !hasID && o.Selector == "" {
+		return nil, errOOBNoTarget
+	}
+	return n, nil
+}
@@ -0,0 +1,45 @@
+package hx_test
+
+import (
+	"strings"
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx"
+	"github.com/alarbada/gomponents/internal/assert"
+)
+
+func TestResponse(t *testing.T) {
+	t.Run("renders the primary node followed by the out-of-band swaps", func(t *testing.T) {
+		n := hx.Response(g.El("li", g.Attr("id", "todo-1")),
+			hx.OOB{Node: g.El("span", g.Attr("id", "count"))},
+			hx.OOB{Node: g.El("div", g.Attr("id", "sidebar")), Strategy: "innerHTML"},
+			hx.OOB{Node: g.El("div", g.Attr("class", "toast")), Strategy: "beforeend", Selector: "#toasts"},
+			hx.OOB{Node: g.El("p"), Selector: "#message"},
+		)
+		assert.Equal(t, `<li id="todo-1"></li>`+
+			`<span id="count" hx-swap-oob="true"></span>`+
+			`<div id="sidebar" hx-swap-oob="innerHTML"></div>`+
+			`<div hx-swap-oob="beforeend:#toasts" class="toast"></div>`+
+			`<p hx-swap-oob="outerHTML:#message"></p>`, n)
+	})
+
+	t.Run("allows a nil primary node", func(t *testing.T) {
+		n := hx.Response(nil, hx.OOB{Node: g.El("span", g.Attr("id", "count"))})
+		assert.Equal(t, `<span id="count" hx-swap-oob="true"></span>`, n)
+	})
+
+	t.Run("errors on out-of-band swaps without id or selector", func(t *testing.T) {
+		var b strings.Builder
+		err := hx.Response(g.El("div"), hx.OOB{Node: g.El("span")}).Render(&b)
+		assert.Error(t, err)
+		if b.Len() > 0 {
+			t.Fatal("rendered", b.String())
+		}
+	})
+
+	t.Run("errors on out-of-band swaps that are not elements", func(t *testing.T) {
+		err := hx.Response(g.El("div"), hx.OOB{Node: g.Fragment(), Selector: "#a"}).Render(&strings.Builder{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,24 @@
+// Package preload provides attributes for the htmx preload extension, which loads links before they're clicked.
+// Enable and On enable the extension with an hx-ext attribute.
+// See https://htmx.org/extensions/preload/
+package preload
+
+import (
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx"
+)
+
+// Enable preloading on mousedown, for the element or the links and hx-get elements inside it.
+func Enable() g.Node {
+	return g.Group([]g.Node{hx.Ext("preload"), g.Attr("preload")})
+}
+
+// On preloads on the given event, like "mouseover" or "init".
+func On(event string) g.Node {
+	return g.Group([]g.Node{hx.Ext("preload"), g.Attr("preload", event)})
+}
+
+// Images also preloads the images in the preloaded content.
+func Images() g.Node {
+	return g.Attr("preload-images", "true")
+}
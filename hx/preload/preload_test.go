@@ -0,0 +1,20 @@
+package preload_test
+
+import (
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx/preload"
+	"github.com/alarbada/gomponents/internal/assert"
+)
+
+func TestAttributes(t *testing.T) {
+	t.Run("enables preloading and the extension", func(t *testing.T) {
+		assert.Equal(t, `<a preload hx-ext="preload"></a>`, g.El("a", preload.Enable()))
+	})
+
+	t.Run("preloads on an event with images", func(t *testing.T) {
+		assert.Equal(t, `<a preload="mouseover" preload-images="true" hx-ext="preload"></a>`,
+			g.El("a", preload.On("mouseover"), preload.Images()))
+	})
+}
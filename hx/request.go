@@ -0,0 +1,46 @@
+package hx
+
+import (
+	"net/http"
+)
+
+// RequestInfo has the htmx request headers, with a field for each header.
+// See https://htmx.org/reference/#request_headers
+type RequestInfo struct {
+	// Request is true for all requests made by htmx (HX-Request).
+	Request bool
+	// Boosted is true for requests from an element using hx-boost (HX-Boosted).
+	Boosted bool
+	// HistoryRestoreRequest is true when restoring history after a local history cache miss (HX-History-Restore-Request).
+	HistoryRestoreRequest bool
+	// Target is the id of the target element, if it has one (HX-Target).
+	Target string
+	// Trigger is the id of the triggered element, if it has one (HX-Trigger).
+	Trigger string
+	// TriggerName is the name of the triggered element, if it has one (HX-Trigger-Name).
+	TriggerName string
+	// CurrentURL is the current URL of the browser (HX-Current-URL).
+	CurrentURL string
+	// Prompt is the user response to an hx-prompt (HX-Prompt).
+	Prompt string
+}
+
+// Request returns the htmx request headers of r.
+func Request(r *http.Request) RequestInfo {
+	return RequestInfo{
+		Request:               r.Header.Get("HX-Request") == "true",
+		Boosted:               r.Header.Get("HX-Boosted") == "true",
+		HistoryRestoreRequest: r.Header.Get("HX-History-Restore-Request") == "true",
+		Target:                r.Header.Get("HX-Target"),
+		Trigger:               r.Header.Get("HX-Trigger"),
+		TriggerName:           r.Header.Get("HX-Trigger-Name"),
+		CurrentURL:            r.Header.Get("HX-Current-URL"),
+		Prompt:                r.Header.Get("HX-Prompt"),
+	}
+}
+
+// Partial reports whether the request only expects a fragment of the page in the response.
+// That's the case for htmx requests, except boosted and history restore requests, which expect the full page.
+func (i RequestInfo) Partial() bool {
+	return i.Request && !i.Boosted && !i.HistoryRestoreRequest
+}
@@ -0,0 +1,64 @@
+package hx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alarbada/gomponents/hx"
+)
+
+func TestRequest(t *testing.T) {
+	t.Run("reads all htmx request headers", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("HX-Request", "true")
+		r.Header.Set("HX-Boosted", "true")
+		r.Header.Set("HX-History-Restore-Request", "true")
+		r.Header.Set("HX-Target", "content")
+		r.Header.Set("HX-Trigger", "button")
+		r.Header.Set("HX-Trigger-Name", "save")
+		r.Header.Set("HX-Current-URL", "http://example.com/todos")
+		r.Header.Set("HX-Prompt", "yes")
+
+		expected := hx.RequestInfo{
+			Request:               true,
+			Boosted:               true,
+			HistoryRestoreRequest: true,
+			Target:                "content",
+			Trigger:               "button",
+			TriggerName:           "save",
+			CurrentURL:            "http://example.com/todos",
+			Prompt:                "yes",
+		}
+		if info := hx.Request(r); info != expected {
+			t.Fatalf("info is %+v", info)
+		}
+	})
+
+	t.Run("is empty for normal requests", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if info := hx.Request(r); info != (hx.RequestInfo{}) {
+			t.Fatalf("info is %+v", info)
+		}
+	})
+}
+
+func TestRequestInfo_Partial(t *testing.T) {
+	cases := map[string]struct {
+		info    hx.RequestInfo
+		partial bool
+	}{
+		"normal request":          {hx.RequestInfo{}, false},
+		"htmx request":            {hx.RequestInfo{Request: true}, true},
+		"boosted request":         {hx.RequestInfo{Request: true, Boosted: true}, false},
+		"history restore request": {hx.RequestInfo{Request: true, HistoryRestoreRequest: true}, false},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if c.info.Partial() != c.partial {
+				t.FailNow()
+			}
+		})
+	}
+}
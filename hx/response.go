@@ -0,0 +1,114 @@
+package hx
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// This file has helpers for setting htmx response headers.
+// They take a http.ResponseWriter, so they work with both http.Adapt handlers and gin handlers (through c.Writer).
+// See https://htmx.org/reference/#response_headers
+
+// SetTrigger sets the HX-Trigger response header, to trigger the given client-side events.
+func SetTrigger(w http.ResponseWriter, events ...string) {
+	w.Header().Set("HX-Trigger", strings.Join(events, ", "))
+}
+
+// SetTriggerJSON sets the HX-Trigger response header, to trigger client-side events with details.
+// The events map event names to their details, which are encoded as JSON.
+func SetTriggerJSON(w http.ResponseWriter, events map[string]any) error {
+	return setJSON(w, "HX-Trigger", events)
+}
+
+// SetTriggerAfterSwap is like SetTrigger, but the events are triggered after the swap step.
+func SetTriggerAfterSwap(w http.ResponseWriter, events ...string) {
+	w.Header().Set("HX-Trigger-After-Swap", strings.Join(events, ", "))
+}
+
+// SetTriggerAfterSwapJSON is like SetTriggerJSON, but the events are triggered after the swap step.
+func SetTriggerAfterSwapJSON(w http.ResponseWriter, events map[string]any) error {
+	return setJSON(w, "HX-Trigger-After-Swap", events)
+}
+
+// SetTriggerAfterSettle is like SetTrigger, but the events are triggered after the settle step.
+func SetTriggerAfterSettle(w http.ResponseWriter, events ...string) {
+	w.Header().Set("HX-Trigger-After-Settle", strings.Join(events, ", "))
+}
+
+// SetTriggerAfterSettleJSON is like SetTriggerJSON, but the events are triggered after the settle step.
+func SetTriggerAfterSettleJSON(w http.ResponseWriter, events map[string]any) error {
+	return setJSON(w, "HX-Trigger-After-Settle", events)
+}
+
+// SetRedirect sets the HX-Redirect response header, to do a full page client-side redirect to url.
+func SetRedirect(w http.ResponseWriter, url string) {
+	w.Header().Set("HX-Redirect", url)
+}
+
+// Location for the HX-Location response header. Only Path is required.
+// See https://htmx.org/headers/hx-location/
+type Location struct {
+	Path    string            `json:"path"`
+	Source  string            `json:"source,omitempty"`
+	Event   string            `json:"event,omitempty"`
+	Handler string            `json:"handler,omitempty"`
+	Target  string            `json:"target,omitempty"`
+	Swap    string            `json:"swap,omitempty"`
+	Select  string            `json:"select,omitempty"`
+	Values  map[string]any    `json:"values,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// SetLocation sets the HX-Location response header, to do a client-side redirect without a full page reload.
+// If only the Path of l is set, the header is just the path. Otherwise, l is encoded as JSON.
+func SetLocation(w http.ResponseWriter, l Location) error {
+	if l.Source == "" && l.Event == "" && l.Handler == "" && l.Target == "" && l.Swap == "" && l.Select == "" &&
+		l.Values == nil && l.Headers == nil {
+		w.Header().Set("HX-Location", l.Path)
+		return nil
+	}
+	return setJSON(w, "HX-Location", l)
+}
+
+// SetPushUrl sets the HX-Push-Url response header, to push url into the browser history.
+// Use "false" to prevent the history from being updated.
+func SetPushUrl(w http.ResponseWriter, url string) {
+	w.Header().Set("HX-Push-Url", url)
+}
+
+// SetReplaceUrl sets the HX-Replace-Url response header, to replace the current url in the browser location bar.
+// Use "false" to prevent the location from being updated.
+func SetReplaceUrl(w http.ResponseWriter, url string) {
+	w.Header().Set("HX-Replace-Url", url)
+}
+
+// SetReswap sets the HX-Reswap response header, to change how the response is swapped.
+// The value has the same format as the hx-swap attribute.
+func SetReswap(w http.ResponseWriter, how string) {
+	w.Header().Set("HX-Reswap", how)
+}
+
+// SetRetarget sets the HX-Retarget response header, a CSS selector to change the target of the swap.
+func SetRetarget(w http.ResponseWriter, target string) {
+	w.Header().Set("HX-Retarget", target)
+}
+
+// SetReselect sets the HX-Reselect response header, a CSS selector to choose which part of the response is swapped.
+func SetReselect(w http.ResponseWriter, target string) {
+	w.Header().Set("HX-Reselect", target)
+}
+
+// SetRefresh sets the HX-Refresh response header, to do a full refresh of the page.
+func SetRefresh(w http.ResponseWriter) {
+	w.Header().Set("HX-Refresh", "true")
+}
+
+func setJSON(w http.ResponseWriter, name string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	w.Header().Set(name, string(b))
+	return nil
+}
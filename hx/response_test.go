@@ -0,0 +1,91 @@
+package hx_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alarbada/gomponents/hx"
+)
+
+func TestResponseHeaders(t *testing.T) {
+	t.Run("sets simple headers", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		hx.SetTrigger(w, "saved", "closeModal")
+		hx.SetTriggerAfterSwap(w, "swapped")
+		hx.SetTriggerAfterSettle(w, "settled")
+		hx.SetRedirect(w, "/login")
+		hx.SetPushUrl(w, "/todos")
+		hx.SetReplaceUrl(w, "false")
+		hx.SetReswap(w, "outerHTML")
+		hx.SetRetarget(w, "#errors")
+		hx.SetReselect(w, "#content")
+		hx.SetRefresh(w)
+
+		expected := map[string]string{
+			"HX-Trigger":              "saved, closeModal",
+			"HX-Trigger-After-Swap":   "swapped",
+			"HX-Trigger-After-Settle": "settled",
+			"HX-Redirect":             "/login",
+			"HX-Push-Url":             "/todos",
+			"HX-Replace-Url":          "false",
+			"HX-Reswap":               "outerHTML",
+			"HX-Retarget":             "#errors",
+			"HX-Reselect":             "#content",
+			"HX-Refresh":              "true",
+		}
+		for name, value := range expected {
+			if v := w.Header().Get(name); v != value {
+				t.Errorf("%v is %v", name, v)
+			}
+		}
+	})
+
+	t.Run("sets trigger headers with JSON details", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		if err := hx.SetTriggerJSON(w, map[string]any{"showMessage": map[string]string{"level": "info"}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := hx.SetTriggerAfterSwapJSON(w, map[string]any{"count": 3}); err != nil {
+			t.Fatal(err)
+		}
+		if err := hx.SetTriggerAfterSettleJSON(w, map[string]any{"done": nil}); err != nil {
+			t.Fatal(err)
+		}
+
+		if v := w.Header().Get("HX-Trigger"); v != `{"showMessage":{"level":"info"}}` {
+			t.Error("HX-Trigger is", v)
+		}
+		if v := w.Header().Get("HX-Trigger-After-Swap"); v != `{"count":3}` {
+			t.Error("HX-Trigger-After-Swap is", v)
+		}
+		if v := w.Header().Get("HX-Trigger-After-Settle"); v != `{"done":null}` {
+			t.Error("HX-Trigger-After-Settle is", v)
+		}
+	})
+
+	t.Run("sets location as path if only path given", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		if err := hx.SetLocation(w, hx.Location{Path: "/todos"}); err != nil {
+			t.Fatal(err)
+		}
+		if v := w.Header().Get("HX-Location"); v != "/todos" {
+			t.Error("HX-Location is", v)
+		}
+	})
+
+	t.Run("sets location as JSON if more than path given", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		if err := hx.SetLocation(w, hx.Location{Path: "/todos", Target: "#main", Values: map[string]any{"page": 2}}); err != nil {
+			t.Fatal(err)
+		}
+		if v := w.Header().Get("HX-Location"); v != `{"path":"/todos","target":"#main","values":{"page":2}}` {
+			t.Error("HX-Location is", v)
+		}
+	})
+
+	t.Run("errors if details cannot be encoded", func(t *testing.T) {
+		if err := hx.SetTriggerJSON(httptest.NewRecorder(), map[string]any{"bad": func() {}}); err == nil {
+			t.Fatal("error is nil")
+		}
+	})
+}
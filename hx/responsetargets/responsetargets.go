@@ -0,0 +1,46 @@
+// Package responsetargets provides attributes for the htmx response-targets extension,
+// which swaps error responses into other targets than the normal one.
+// All attributes enable the extension with an hx-ext attribute.
+// See https://htmx.org/extensions/response-targets/
+package responsetargets
+
+import (
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx"
+)
+
+// Target for responses with the given status code, which may end in wildcards, like "404", "40*", or "5*".
+// Target panics on invalid status codes.
+func Target(code, selector string) g.Node {
+	if !validCode(code) {
+		panic("invalid status code " + code)
+	}
+	return withExt(g.Attr("hx-target-"+code, selector))
+}
+
+// TargetError for all 4xx and 5xx responses.
+func TargetError(selector string) g.Node {
+	return withExt(g.Attr("hx-target-error", selector))
+}
+
+func withExt(n g.Node) g.Node {
+	return g.Group([]g.Node{hx.Ext("response-targets"), n})
+}
+
+// validCode is one to three digits, optionally followed by wildcards to make three characters.
+func validCode(code string) bool {
+	if code == "" || len(code) > 3 || code[0] < '1' || code[0] > '5' {
+		return false
+	}
+	wildcard := false
+	for _, c := range code[1:] {
+		switch {
+		case c == '*' || c == 'x':
+			wildcard = true
+		case c >= '0' && c <= '9' && !wildcard:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+package responsetargets_test
+
+import (
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx/responsetargets"
+	"github.com/alarbada/gomponents/internal/assert"
+)
+
+func TestTarget(t *testing.T) {
+	t.Run("enables the extension once for several targets", func(t *testing.T) {
+		n := g.El("form", responsetargets.Target("404", "#not-found"), responsetargets.Target("5*", "#error"))
+		assert.Equal(t, `<form hx-target-404="#not-found" hx-target-5*="#error" hx-ext="response-targets"></form>`, n)
+	})
+
+	t.Run("panics on invalid status codes", func(t *testing.T) {
+		for _, code := range []string{"", "4040", "600", "4*4", "abc"} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatal("did not panic on", code)
+					}
+				}()
+				responsetargets.Target(code, "#error")
+			}()
+		}
+	})
+}
+
+func TestTargetError(t *testing.T) {
+	t.Run("targets all errors", func(t *testing.T) {
+		n := g.El("form", responsetargets.TargetError("#error"))
+		assert.Equal(t, `<form hx-target-error="#error" hx-ext="response-targets"></form>`, n)
+	})
+}
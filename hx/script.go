@@ -0,0 +1,40 @@
+package hx
+
+import (
+	_ "embed"
+	"net/http"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/internal/asset"
+)
+
+// Version of the embedded htmx script.
+const Version = "1.9.12"
+
+// ScriptPath is the path prefix Script expects ScriptHandler to be mounted at.
+const ScriptPath = "/_gomponents/"
+
+//go:embed htmx.min.js
+var script []byte
+
+var scriptAsset = asset.New("htmx.min.js", script)
+
+// ScriptHandler serves the embedded htmx script, so pages don't depend on a CDN.
+// The file name includes a hash of the content, so it's served with headers to cache it forever.
+// Mount it at ScriptPath, or any other path used with ScriptAt. With a http.ServeMux:
+//
+//	mux.Handle(hx.ScriptPath, hx.ScriptHandler())
+func ScriptHandler() http.Handler {
+	return scriptAsset
+}
+
+// Script element for the embedded htmx script served by ScriptHandler at ScriptPath,
+// with an integrity attribute for Subresource Integrity.
+func Script() g.Node {
+	return ScriptAt(ScriptPath)
+}
+
+// ScriptAt is like Script, but for ScriptHandler mounted at the given path prefix.
+func ScriptAt(prefix string) g.Node {
+	return scriptAsset.Script(prefix)
+}
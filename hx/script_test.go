@@ -0,0 +1,70 @@
+package hx_test
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/alarbada/gomponents/hx"
+)
+
+func TestScript(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(hx.ScriptPath, hx.ScriptHandler())
+
+	var b strings.Builder
+	if err := hx.Script().Render(&b); err != nil {
+		t.Fatal(err)
+	}
+	matches := regexp.MustCompile(`^<script src="(/_gomponents/htmx\.min\.[0-9a-f]{12}\.js)" integrity="(sha384-[^"]+)"></script>$`).
+		FindStringSubmatch(b.String())
+	if matches == nil {
+		t.Fatal("script is", b.String())
+	}
+	src, integrity := matches[1], matches[2]
+
+	t.Run("serves the script with immutable caching and a matching integrity", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, src, nil))
+		result := recorder.Result()
+		body, err := io.ReadAll(result.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result.StatusCode != http.StatusOK {
+			t.Fatal("status code is", result.StatusCode)
+		}
+		if cc := result.Header.Get("Cache-Control"); cc != "public, max-age=31536000, immutable" {
+			t.Fatal("cache control is", cc)
+		}
+		if ct := result.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/javascript") {
+			t.Fatal("content type is", ct)
+		}
+		sum := sha512.Sum384(body)
+		if "sha384-"+base64.StdEncoding.EncodeToString(sum[:]) != integrity {
+			t.Fatal("integrity does not match")
+		}
+	})
+
+	t.Run("responds with 404 for other file names", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/_gomponents/htmx.min.js", nil))
+		if recorder.Code != http.StatusNotFound {
+			t.Fatal("status code is", recorder.Code)
+		}
+	})
+
+	t.Run("references the script at another path", func(t *testing.T) {
+		var b strings.Builder
+		_ = hx.ScriptAt("/static/").Render(&b)
+		if !strings.HasPrefix(b.String(), `<script src="/static/htmx.min.`) {
+			t.Fatal("script is", b.String())
+		}
+	})
+}
@@ -0,0 +1,17 @@
+// Package sse provides attributes for the htmx Server Sent Events extension.
+// Connect enables the extension with an hx-ext attribute, and the other attributes must be used on the
+// connected element or its descendants.
+// See https://htmx.org/extensions/server-sent-events/ and http.SSE for sending events.
+package sse
+
+import (
+	"strings"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx"
+)
+
+func Connect(url string) g.Node    { return g.Group([]g.Node{hx.Ext("sse"), g.Attr("sse-connect", url)}) }
+func Swap(events ...string) g.Node { return g.Attr("sse-swap", strings.Join(events, ",")) }
+func Close(event string) g.Node    { return g.Attr("sse-close", event) }
+func Trigger(event string) g.Node  { return hx.Trigger("sse:" + event) }
@@ -0,0 +1,20 @@
+package sse_test
+
+import (
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx/sse"
+	"github.com/alarbada/gomponents/internal/assert"
+)
+
+func TestAttributes(t *testing.T) {
+	t.Run("connects and enables the extension", func(t *testing.T) {
+		n := g.El("div", sse.Connect("/events"), g.El("div", sse.Swap("message", "update")), g.El("div", sse.Close("done")))
+		assert.Equal(t, `<div sse-connect="/events" hx-ext="sse"><div sse-swap="message,update"></div><div sse-close="done"></div></div>`, n)
+	})
+
+	t.Run("triggers on an event", func(t *testing.T) {
+		assert.Equal(t, ` hx-trigger="sse:update"`, sse.Trigger("update"))
+	})
+}
@@ -0,0 +1,130 @@
+package hx
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	g "github.com/alarbada/gomponents"
+)
+
+// SwapSpec is a typed hx-swap specification, which Renders to an attribute with name "hx-swap".
+// Create one with a swap strategy function like SwapOuterHTML, and add modifiers with its methods.
+// Invalid modifiers make the methods panic.
+// See https://htmx.org/attributes/hx-swap/
+type SwapSpec struct {
+	strategy  string
+	modifiers []string
+}
+
+func SwapInnerHTML() SwapSpec   { return SwapSpec{strategy: "innerHTML"} }
+func SwapOuterHTML() SwapSpec   { return SwapSpec{strategy: "outerHTML"} }
+func SwapBeforeBegin() SwapSpec { return SwapSpec{strategy: "beforebegin"} }
+func SwapAfterBegin() SwapSpec  { return SwapSpec{strategy: "afterbegin"} }
+func SwapBeforeEnd() SwapSpec   { return SwapSpec{strategy: "beforeend"} }
+func SwapAfterEnd() SwapSpec    { return SwapSpec{strategy: "afterend"} }
+func SwapDelete() SwapSpec      { return SwapSpec{strategy: "delete"} }
+func SwapNone() SwapSpec        { return SwapSpec{strategy: "none"} }
+
+// Transition uses the View Transitions API for the swap.
+func (s SwapSpec) Transition() SwapSpec {
+	return s.with("transition:true")
+}
+
+// Swap waits d between receiving the response and swapping the content.
+func (s SwapSpec) Swap(d time.Duration) SwapSpec {
+	return s.with("swap:" + formatDuration(d))
+}
+
+// Settle waits d between the swap and the settle step.
+func (s SwapSpec) Settle(d time.Duration) SwapSpec {
+	return s.with("settle:" + formatDuration(d))
+}
+
+// IgnoreTitle keeps the page title, even if the response has a title element.
+func (s SwapSpec) IgnoreTitle() SwapSpec {
+	return s.with("ignoreTitle:true")
+}
+
+// Scroll the target element to its "top" or "bottom" after the swap.
+func (s SwapSpec) Scroll(position string) SwapSpec {
+	return s.with("scroll:" + checkPosition(position, false))
+}
+
+// ScrollTarget is like Scroll, but scrolls the element matching selector instead. Use "window" to scroll the page.
+func (s SwapSpec) ScrollTarget(selector, position string) SwapSpec {
+	return s.with("scroll:" + checkSelector(selector) + ":" + checkPosition(position, false))
+}
+
+// Show the "top" or "bottom" of the target element after the swap, or "none" to disable showing it.
+func (s SwapSpec) Show(position string) SwapSpec {
+	return s.with("show:" + checkPosition(position, true))
+}
+
+// ShowTarget is like Show, but shows the element matching selector instead. Use "window" to show the page.
+func (s SwapSpec) ShowTarget(selector, position string) SwapSpec {
+	return s.with("show:" + checkSelector(selector) + ":" + checkPosition(position, false))
+}
+
+// FocusScroll sets whether to scroll to the focused element after the swap.
+func (s SwapSpec) FocusScroll(enabled bool) SwapSpec {
+	if enabled {
+		return s.with("focus-scroll:true")
+	}
+	return s.with("focus-scroll:false")
+}
+
+// String satisfies fmt.Stringer, and returns the value of the attribute, for example to use with SetReswap.
+func (s SwapSpec) String() string {
+	return strings.Join(append([]string{s.strategy}, s.modifiers...), " ")
+}
+
+// Render satisfies g.Node.
+func (s SwapSpec) Render(w io.Writer) error {
+	return Swap(s.String()).Render(w)
+}
+
+func (s SwapSpec) Type() g.NodeType {
+	return g.AttributeType
+}
+
+// with returns a copy of s with the modifier added, so a SwapSpec can be reused as a base for others.
+func (s SwapSpec) with(modifier string) SwapSpec {
+	if s.strategy == "" {
+		panic("swap spec must be created with a swap strategy function")
+	}
+
+	modifiers := make([]string, len(s.modifiers), len(s.modifiers)+1)
+	copy(modifiers, s.modifiers)
+	s.modifiers = append(modifiers, modifier)
+	return s
+}
+
+// formatDuration in the htmx time format, like "500ms" or "2s".
+func formatDuration(d time.Duration) string {
+	if d < 0 {
+		panic("duration must not be negative")
+	}
+	if d%time.Millisecond != 0 {
+		panic("duration must be a whole number of milliseconds")
+	}
+	if d%time.Second == 0 && d > 0 {
+		return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+	}
+	return strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+}
+
+func checkPosition(position string, allowNone bool) string {
+	if position == "top" || position == "bottom" || (allowNone && position == "none") {
+		return position
+	}
+	panic("invalid position " + position)
+}
+
+func checkSelector(selector string) string {
+	if selector == "" || strings.ContainsAny(selector, " \t\n") {
+		panic("selector must be non-empty and without whitespace")
+	}
+	return selector
+}
@@ -0,0 +1,71 @@
+package hx_test
+
+import (
+	"testing"
+	"time"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx"
+	"github.com/alarbada/gomponents/internal/assert"
+)
+
+func TestSwapSpec(t *testing.T) {
+	t.Run("renders the swap strategies", func(t *testing.T) {
+		cases := map[string]func() hx.SwapSpec{
+			"innerHTML":   hx.SwapInnerHTML,
+			"outerHTML":   hx.SwapOuterHTML,
+			"beforebegin": hx.SwapBeforeBegin,
+			"afterbegin":  hx.SwapAfterBegin,
+			"beforeend":   hx.SwapBeforeEnd,
+			"afterend":    hx.SwapAfterEnd,
+			"delete":      hx.SwapDelete,
+			"none":        hx.SwapNone,
+		}
+		for strategy, fn := range cases {
+			assert.Equal(t, ` hx-swap="`+strategy+`"`, fn())
+		}
+	})
+
+	t.Run("renders modifiers in order", func(t *testing.T) {
+		s := hx.SwapOuterHTML().Swap(time.Second).Settle(100*time.Millisecond).Scroll("top").Transition().
+			IgnoreTitle().ShowTarget("#list", "bottom").FocusScroll(false)
+		assert.Equal(t, `<div hx-swap="outerHTML swap:1s settle:100ms scroll:top transition:true ignoreTitle:true show:#list:bottom focus-scroll:false"></div>`,
+			g.El("div", s))
+	})
+
+	t.Run("does not share modifiers between specs from the same base", func(t *testing.T) {
+		base := hx.SwapInnerHTML().Settle(0)
+		a := base.Show("none")
+		b := base.ScrollTarget("window", "bottom")
+		if a.String() != "innerHTML settle:0ms show:none" || b.String() != "innerHTML settle:0ms scroll:window:bottom" {
+			t.Fatal("specs are", a, b)
+		}
+	})
+
+	t.Run("panics on invalid modifiers", func(t *testing.T) {
+		cases := map[string]func(){
+			"negative duration":   func() { hx.SwapInnerHTML().Swap(-time.Second) },
+			"sub-millisecond":     func() { hx.SwapInnerHTML().Settle(time.Microsecond) },
+			"invalid position":    func() { hx.SwapInnerHTML().Scroll("middle") },
+			"none scroll target":  func() { hx.SwapInnerHTML().ScrollTarget("#a", "none") },
+			"selector with space": func() { hx.SwapInnerHTML().ShowTarget("#a b", "top") },
+			"zero value":          func() { hx.SwapSpec{}.Transition() },
+		}
+		for name, fn := range cases {
+			t.Run(name, func(t *testing.T) {
+				assertPanics(t, fn)
+			})
+		}
+	})
+}
+
+func assertPanics(t *testing.T, fn func()) {
+	t.Helper()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("did not panic")
+		}
+	}()
+	fn()
+}
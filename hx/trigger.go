@@ -0,0 +1,151 @@
+package hx
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	g "github.com/alarbada/gomponents"
+)
+
+// TriggerSpec is a typed hx-trigger specification, which Renders to an attribute with name "hx-trigger".
+// Create one with OnEvent or Every, and add modifiers with its methods. Use Triggers for more than one trigger.
+// Invalid events and modifiers make the functions and methods panic.
+// See https://htmx.org/attributes/hx-trigger/
+type TriggerSpec struct {
+	event     string
+	filter    string
+	modifiers []string
+}
+
+// OnEvent triggers on the named event, like "click" or "keyup".
+func OnEvent(event string) TriggerSpec {
+	if event == "" || strings.ContainsAny(event, " \t\n,[]") {
+		panic("invalid event name " + event)
+	}
+	return TriggerSpec{event: event}
+}
+
+// Every triggers by polling every d.
+func Every(d time.Duration) TriggerSpec {
+	if d <= 0 {
+		panic("polling interval must be positive")
+	}
+	return TriggerSpec{event: "every " + formatDuration(d)}
+}
+
+// Filter the trigger with a JavaScript expression, like "ctrlKey".
+func (s TriggerSpec) Filter(expr string) TriggerSpec {
+	s.check()
+	if expr == "" || strings.ContainsAny(expr, "[]") {
+		panic("invalid filter " + expr)
+	}
+	s.filter = expr
+	return s
+}
+
+// Once triggers only once.
+func (s TriggerSpec) Once() TriggerSpec {
+	return s.with("once")
+}
+
+// Changed triggers only if the value of the element has changed.
+func (s TriggerSpec) Changed() TriggerSpec {
+	return s.with("changed")
+}
+
+// Delay waits d before triggering, and restarts waiting if the event is seen again.
+func (s TriggerSpec) Delay(d time.Duration) TriggerSpec {
+	return s.with("delay:" + formatDuration(d))
+}
+
+// Throttle triggers at most once every d.
+func (s TriggerSpec) Throttle(d time.Duration) TriggerSpec {
+	return s.with("throttle:" + formatDuration(d))
+}
+
+// From listens for the event on the element matching selector instead.
+// Besides a CSS selector, the extended forms "document", "window", "closest <selector>", "find <selector>",
+// "next", "next <selector>", "previous" and "previous <selector>" are supported.
+func (s TriggerSpec) From(selector string) TriggerSpec {
+	if keyword, rest, ok := strings.Cut(selector, " "); ok {
+		switch keyword {
+		case "closest", "find", "next", "previous":
+			return s.with("from:" + keyword + " " + checkSelector(rest))
+		}
+	}
+	return s.with("from:" + checkSelector(selector))
+}
+
+// Target only triggers if the target of the event matches selector.
+func (s TriggerSpec) Target(selector string) TriggerSpec {
+	return s.with("target:" + checkSelector(selector))
+}
+
+// Consume stops the event from propagating to parent elements.
+func (s TriggerSpec) Consume() TriggerSpec {
+	return s.with("consume")
+}
+
+// Queue decides which events to queue while a request is in flight: "first", "last", "all", or "none".
+func (s TriggerSpec) Queue(which string) TriggerSpec {
+	switch which {
+	case "first", "last", "all", "none":
+		return s.with("queue:" + which)
+	default:
+		panic("invalid queue option " + which)
+	}
+}
+
+// String satisfies fmt.Stringer, and returns the value of the attribute.
+func (s TriggerSpec) String() string {
+	var b strings.Builder
+	b.WriteString(s.event)
+	if s.filter != "" {
+		if strings.HasPrefix(s.event, "every ") {
+			b.WriteString(" ")
+		}
+		b.WriteString("[")
+		b.WriteString(s.filter)
+		b.WriteString("]")
+	}
+	for _, m := range s.modifiers {
+		b.WriteString(" ")
+		b.WriteString(m)
+	}
+	return b.String()
+}
+
+// Render satisfies g.Node.
+func (s TriggerSpec) Render(w io.Writer) error {
+	return Trigger(s.String()).Render(w)
+}
+
+func (s TriggerSpec) Type() g.NodeType {
+	return g.AttributeType
+}
+
+// Triggers combines several trigger specifications into one hx-trigger attribute.
+func Triggers(specs ...TriggerSpec) g.Node {
+	values := make([]string, len(specs))
+	for i, s := range specs {
+		values[i] = s.String()
+	}
+	return Trigger(strings.Join(values, ", "))
+}
+
+func (s TriggerSpec) check() {
+	if s.event == "" {
+		panic("trigger spec must be created with OnEvent or Every")
+	}
+}
+
+// with returns a copy of s with the modifier added, so a TriggerSpec can be reused as a base for others.
+func (s TriggerSpec) with(modifier string) TriggerSpec {
+	s.check()
+
+	modifiers := make([]string, len(s.modifiers), len(s.modifiers)+1)
+	copy(modifiers, s.modifiers)
+	s.modifiers = append(modifiers, modifier)
+	return s
+}
@@ -0,0 +1,50 @@
+package hx_test
+
+import (
+	"testing"
+	"time"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx"
+	"github.com/alarbada/gomponents/internal/assert"
+)
+
+func TestTriggerSpec(t *testing.T) {
+	t.Run("renders an event with modifiers", func(t *testing.T) {
+		s := hx.OnEvent("keyup").Changed().Delay(500 * time.Millisecond).From("#search")
+		assert.Equal(t, `<input hx-trigger="keyup changed delay:500ms from:#search">`, g.El("input", s))
+	})
+
+	t.Run("renders all modifiers", func(t *testing.T) {
+		s := hx.OnEvent("click").Filter("ctrlKey").Once().Throttle(2 * time.Second).From("closest form").
+			Target("#button").Consume().Queue("last")
+		assert.Equal(t, ` hx-trigger="click[ctrlKey] once throttle:2s from:closest form target:#button consume queue:last"`, s)
+	})
+
+	t.Run("renders polling", func(t *testing.T) {
+		assert.Equal(t, ` hx-trigger="every 1s"`, hx.Every(time.Second))
+		assert.Equal(t, ` hx-trigger="every 1500ms [isVisible()]"`, hx.Every(1500*time.Millisecond).Filter("isVisible()"))
+	})
+
+	t.Run("combines several triggers", func(t *testing.T) {
+		n := hx.Triggers(hx.OnEvent("load"), hx.OnEvent("refresh").From("body"))
+		assert.Equal(t, ` hx-trigger="load, refresh from:body"`, n)
+	})
+
+	t.Run("panics on invalid events and modifiers", func(t *testing.T) {
+		cases := map[string]func(){
+			"empty event":           func() { hx.OnEvent("") },
+			"event with space":      func() { hx.OnEvent("key up") },
+			"non-positive interval": func() { hx.Every(0) },
+			"filter with brackets":  func() { hx.OnEvent("click").Filter("a[0]") },
+			"invalid queue":         func() { hx.OnEvent("click").Queue("some") },
+			"invalid from":          func() { hx.OnEvent("click").From("div span") },
+			"zero value":            func() { hx.TriggerSpec{}.Once() },
+		}
+		for name, fn := range cases {
+			t.Run(name, func(t *testing.T) {
+				assertPanics(t, fn)
+			})
+		}
+	})
+}
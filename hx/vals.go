@@ -0,0 +1,59 @@
+package hx
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	g "github.com/alarbada/gomponents"
+)
+
+// ValsOf returns an hx-vals attribute with v encoded as JSON, usually a struct or a map.
+// Encoding errors are returned when rendering.
+func ValsOf(v any) g.Node {
+	return jsonAttr{name: "hx-vals", value: v}
+}
+
+// ValsJS returns an hx-vals attribute with a JavaScript expression evaluated by htmx when the request is made.
+// The expression must evaluate to an object, like "{width: window.innerWidth}".
+func ValsJS(expr string) g.Node {
+	return Vals("js:" + expr)
+}
+
+// HeadersOf returns an hx-headers attribute with the headers encoded as JSON.
+func HeadersOf(headers map[string]string) g.Node {
+	return jsonAttr{name: "hx-headers", value: headers}
+}
+
+// HeadersJS returns an hx-headers attribute with a JavaScript expression evaluated by htmx when the request is made.
+// The expression must evaluate to an object, like "{'X-Timezone': Intl.DateTimeFormat().resolvedOptions().timeZone}".
+func HeadersJS(expr string) g.Node {
+	return Headers("js:" + expr)
+}
+
+// jsonAttr is an attribute with a value encoded as JSON when rendering.
+// The JSON is escaped like any other attribute value, so it's safe to use in any double-quoted attribute.
+type jsonAttr struct {
+	name  string
+	value any
+}
+
+// Render satisfies g.Node.
+func (a jsonAttr) Render(w io.Writer) error {
+	b, err := json.Marshal(a.value)
+	if err != nil {
+		return err
+	}
+	return g.Attr(a.name, string(b)).Render(w)
+}
+
+func (a jsonAttr) Type() g.NodeType {
+	return g.AttributeType
+}
+
+// String satisfies fmt.Stringer.
+func (a jsonAttr) String() string {
+	var b strings.Builder
+	_ = a.Render(&b)
+	return b.String()
+}
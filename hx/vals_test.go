@@ -0,0 +1,79 @@
+package hx_test
+
+import (
+	"encoding/json"
+	"html"
+	"reflect"
+	"strings"
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx"
+	"github.com/alarbada/gomponents/internal/assert"
+)
+
+func TestValsOf(t *testing.T) {
+	t.Run("encodes a struct as JSON", func(t *testing.T) {
+		n := hx.ValsOf(struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}{ID: 1, Name: `Party "hat"`})
+		assert.Equal(t, ` hx-vals="{&#34;id&#34;:1,&#34;name&#34;:&#34;Party \&#34;hat\&#34;&#34;}"`, n)
+	})
+
+	t.Run("round-trips through attribute escaping", func(t *testing.T) {
+		vals := map[string]any{"quote": `"'`, "tags": "</div><script>", "amp": "a&b", "n": 1.5}
+		var decoded map[string]any
+		if err := json.Unmarshal([]byte(attrValue(t, hx.ValsOf(vals))), &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(vals, decoded) {
+			t.Fatal("decoded is", decoded)
+		}
+	})
+
+	t.Run("returns encoding errors when rendering", func(t *testing.T) {
+		err := g.El("div", hx.ValsOf(func() {})).Render(&strings.Builder{})
+		assert.Error(t, err)
+	})
+}
+
+func TestValsJS(t *testing.T) {
+	t.Run("prefixes the expression with js:", func(t *testing.T) {
+		assert.Equal(t, ` hx-vals="js:{width: window.innerWidth}"`, hx.ValsJS("{width: window.innerWidth}"))
+	})
+}
+
+func TestHeadersOf(t *testing.T) {
+	t.Run("round-trips through attribute escaping", func(t *testing.T) {
+		headers := map[string]string{"X-CSRF-Token": `a"b<c>&d`}
+		var decoded map[string]string
+		if err := json.Unmarshal([]byte(attrValue(t, hx.HeadersOf(headers))), &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(headers, decoded) {
+			t.Fatal("decoded is", decoded)
+		}
+	})
+}
+
+func TestHeadersJS(t *testing.T) {
+	t.Run("prefixes the expression with js:", func(t *testing.T) {
+		assert.Equal(t, ` hx-headers="js:getHeaders()"`, hx.HeadersJS("getHeaders()"))
+	})
+}
+
+// attrValue renders the attribute and unescapes its value, like a browser would.
+func attrValue(t *testing.T, n g.Node) string {
+	t.Helper()
+
+	var b strings.Builder
+	if err := n.Render(&b); err != nil {
+		t.Fatal(err)
+	}
+	_, value, ok := strings.Cut(b.String(), `="`)
+	if !ok {
+		t.Fatal("no value in", b.String())
+	}
+	return html.UnescapeString(strings.TrimSuffix(value, `"`))
+}
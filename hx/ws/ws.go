@@ -0,0 +1,13 @@
+// Package ws provides attributes for the htmx WebSockets extension.
+// Connect enables the extension with an hx-ext attribute, and Send must be used on the connected element or
+// its descendants.
+// See https://htmx.org/extensions/web-sockets/ and http.Hub for sending messages.
+package ws
+
+import (
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx"
+)
+
+func Connect(url string) g.Node { return g.Group([]g.Node{hx.Ext("ws"), g.Attr("ws-connect", url)}) }
+func Send() g.Node              { return g.Attr("ws-send") }
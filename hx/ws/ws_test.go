@@ -0,0 +1,16 @@
+package ws_test
+
+import (
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/hx/ws"
+	"github.com/alarbada/gomponents/internal/assert"
+)
+
+func TestAttributes(t *testing.T) {
+	t.Run("connects and enables the extension", func(t *testing.T) {
+		n := g.El("div", ws.Connect("/chat"), g.El("form", ws.Send()))
+		assert.Equal(t, `<div ws-connect="/chat" hx-ext="ws"><form ws-send></form></div>`, n)
+	})
+}
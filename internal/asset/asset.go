@@ -0,0 +1,90 @@
+// Package asset serves embedded static files under content-hash file names.
+package asset
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	g "github.com/alarbada/gomponents"
+)
+
+// Asset is a static file with a file name that changes whenever its content does,
+// so it can be cached forever by browsers.
+type Asset struct {
+	name      string
+	content   []byte
+	integrity string
+}
+
+// New Asset with the given file name, like "htmx.min.js", and content.
+// The content hash is inserted before the file extension, like "htmx.min.0123456789ab.js".
+func New(name string, content []byte) *Asset {
+	sum := sha512.Sum384(content)
+
+	ext := path.Ext(name)
+	hashed := strings.TrimSuffix(name, ext) + "." + hex.EncodeToString(sum[:6]) + ext
+
+	return &Asset{
+		name:      hashed,
+		content:   content,
+		integrity: "sha384-" + base64.StdEncoding.EncodeToString(sum[:]),
+	}
+}
+
+// Name is the file name with the content hash.
+func (a *Asset) Name() string {
+	return a.name
+}
+
+// Integrity is the Subresource Integrity value of the content.
+// See https://developer.mozilla.org/en-US/docs/Web/Security/Subresource_Integrity
+func (a *Asset) Integrity() string {
+	return a.integrity
+}
+
+// Script element for the Asset served at the given path prefix, with an integrity attribute,
+// and the given extra attributes, like defer.
+func (a *Asset) Script(prefix string, attrs ...g.Node) g.Node {
+	return g.El("script",
+		g.Attr("src", strings.TrimSuffix(prefix, "/")+"/"+a.name),
+		g.Attr("integrity", a.integrity),
+		g.Group(attrs),
+	)
+}
+
+// ServeHTTP satisfies http.Handler.
+// It serves the content for requests to any path ending in Name, with headers to cache it forever,
+// and a 404 Not Found for all other paths.
+func (a *Asset) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if path.Base(r.URL.Path) != a.name {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType(a.name))
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("ETag", `"`+a.integrity+`"`)
+	http.ServeContent(w, r, a.name, time.Time{}, bytes.NewReader(a.content))
+}
+
+// contentType by file extension, not depending on the system MIME types for the common cases.
+func contentType(name string) string {
+	switch ext := path.Ext(name); ext {
+	case ".js":
+		return "text/javascript; charset=utf-8"
+	case ".css":
+		return "text/css; charset=utf-8"
+	default:
+		if t := mime.TypeByExtension(ext); t != "" {
+			return t
+		}
+		return "application/octet-stream"
+	}
+}
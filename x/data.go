@@ -0,0 +1,90 @@
+package x
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	g "github.com/alarbada/gomponents"
+)
+
+// DataMethod is a JavaScript method added to a component with DataOf.
+type DataMethod struct {
+	name string
+	body string
+}
+
+// Method for DataOf with the given name and JavaScript body.
+// The name can include parameters, like "add(n)". Without them, the method takes no parameters.
+func Method(name, body string) DataMethod {
+	return DataMethod{name: name, body: body}
+}
+
+// DataOf returns an x-data attribute with v encoded as a JavaScript object, with the given methods added.
+// v must encode to a JSON object, so it's usually a struct or a map.
+// Encoding errors are returned when rendering.
+func DataOf(v any, methods ...DataMethod) g.Node {
+	return dataAttr{value: v, methods: methods}
+}
+
+var errNotObject = errors.New("x-data value must encode to a JSON object")
+
+// dataAttr is an x-data attribute with a value encoded as JSON when rendering.
+// The JSON is escaped like any other attribute value, so it's safe to use in the attribute.
+type dataAttr struct {
+	value   any
+	methods []DataMethod
+}
+
+// Render satisfies g.Node.
+func (a dataAttr) Render(w io.Writer) error {
+	object, err := objectLiteral(a.value, a.methods)
+	if err != nil {
+		return err
+	}
+	return Data(object).Render(w)
+}
+
+func (a dataAttr) Type() g.NodeType {
+	return g.AttributeType
+}
+
+// String satisfies fmt.Stringer.
+func (a dataAttr) String() string {
+	var b strings.Builder
+	_ = a.Render(&b)
+	return b.String()
+}
+
+// objectLiteral encodes v as JSON, and adds the methods to the resulting object.
+func objectLiteral(v any, methods []DataMethod) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	object := string(b)
+	if !strings.HasPrefix(object, "{") {
+		return "", errNotObject
+	}
+	if len(methods) == 0 {
+		return object, nil
+	}
+
+	var s strings.Builder
+	s.WriteString(strings.TrimSuffix(object, "}"))
+	for i, m := range methods {
+		if i > 0 || object != "{}" {
+			s.WriteString(", ")
+		}
+		s.WriteString(m.name)
+		if !strings.Contains(m.name, "(") {
+			s.WriteString("()")
+		}
+		s.WriteString(" { ")
+		s.WriteString(m.body)
+		s.WriteString(" }")
+	}
+	s.WriteString("}")
+	return s.String(), nil
+}
@@ -0,0 +1,40 @@
+package x_test
+
+import (
+	"strings"
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/internal/assert"
+	"github.com/alarbada/gomponents/x"
+)
+
+type dropdown struct {
+	Open  bool   `json:"open"`
+	Label string `json:"label"`
+}
+
+func TestDataOf(t *testing.T) {
+	t.Run("encodes a struct as an object", func(t *testing.T) {
+		n := x.DataOf(dropdown{Label: `Party "hat" </div>`})
+		assert.Equal(t, ` x-data="{&#34;open&#34;:false,&#34;label&#34;:&#34;Party \&#34;hat\&#34; \u003c/div\u003e&#34;}"`, n)
+	})
+
+	t.Run("adds methods to the object", func(t *testing.T) {
+		n := x.DataOf(map[string]int{"count": 1},
+			x.Method("increment", "this.count++"),
+			x.Method("add(n)", "this.count += n"),
+		)
+		assert.Equal(t, ` x-data="{&#34;count&#34;:1, increment() { this.count++ }, add(n) { this.count += n }}"`, n)
+	})
+
+	t.Run("adds methods to an empty object", func(t *testing.T) {
+		n := x.DataOf(struct{}{}, x.Method("hello", "alert('hi')"))
+		assert.Equal(t, ` x-data="{hello() { alert(&#39;hi&#39;) }}"`, n)
+	})
+
+	t.Run("returns an error when rendering something that isn't an object", func(t *testing.T) {
+		err := g.El("div", x.DataOf([]int{1})).Render(&strings.Builder{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,46 @@
+package x
+
+import (
+	g "github.com/alarbada/gomponents"
+)
+
+// This file has attributes for the official Alpine plugins, which must be loaded before Alpine.
+// See https://alpinejs.dev/plugins/mask and the other plugin pages.
+
+func Mask(pattern string) g.Node     { return g.Attr("x-mask", pattern) }
+func MaskDynamic(expr string) g.Node { return g.Attr("x-mask:dynamic", expr) }
+func SortGroup(name string) g.Node   { return g.Attr("x-sort:group", name) }
+func SortHandle() g.Node             { return g.Attr("x-sort:handle") }
+func SortIgnore() g.Node             { return g.Attr("x-sort:ignore") }
+func SortItem(key string) g.Node     { return g.Attr("x-sort:item", key) }
+
+// Intersect runs expr when the element enters the viewport, with optional modifiers like "once" or "half".
+func Intersect(expr string, modifiers ...string) g.Node {
+	return g.Attr("x-intersect"+joinModifiers(modifiers), expr)
+}
+
+// IntersectEnter is like Intersect.
+func IntersectEnter(expr string, modifiers ...string) g.Node {
+	return g.Attr("x-intersect:enter"+joinModifiers(modifiers), expr)
+}
+
+// IntersectLeave runs expr when the element leaves the viewport.
+func IntersectLeave(expr string, modifiers ...string) g.Node {
+	return g.Attr("x-intersect:leave"+joinModifiers(modifiers), expr)
+}
+
+// Collapse the element when hidden with x-show, with optional modifiers like "duration.500ms" or "min.50px".
+func Collapse(modifiers ...string) g.Node {
+	return g.Attr("x-collapse" + joinModifiers(modifiers))
+}
+
+// Trap the focus inside the element while expr is true, with optional modifiers like "inert" or "noscroll".
+func Trap(expr string, modifiers ...string) g.Node {
+	return g.Attr("x-trap"+joinModifiers(modifiers), expr)
+}
+
+// Sort the children of the element by dragging, calling the optional handler expression after sorting.
+// More than one handler makes Sort panic.
+func Sort(handler ...string) g.Node {
+	return g.Attr("x-sort", handler...)
+}
@@ -0,0 +1,36 @@
+package x_test
+
+import (
+	"fmt"
+	"testing"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/internal/assert"
+	"github.com/alarbada/gomponents/x"
+)
+
+func TestPluginAttributes(t *testing.T) {
+	cases := map[string]g.Node{
+		`x-mask="99/99/9999"`:                    x.Mask("99/99/9999"),
+		`x-mask:dynamic="$money($input)"`:        x.MaskDynamic("$money($input)"),
+		`x-intersect="shown = true"`:             x.Intersect("shown = true"),
+		`x-intersect.once.half="load()"`:         x.Intersect("load()", "once", "half"),
+		`x-intersect:enter="shown = true"`:       x.IntersectEnter("shown = true"),
+		`x-intersect:leave.full="shown = false"`: x.IntersectLeave("shown = false", "full"),
+		`x-collapse`:                             x.Collapse(),
+		`x-collapse.duration.500ms`:              x.Collapse("duration.500ms"),
+		`x-trap.inert.noscroll="open"`:           x.Trap("open", "inert", "noscroll"),
+		`x-sort`:                                 x.Sort(),
+		`x-sort="handle(item, position)"`:        x.Sort("handle(item, position)"),
+		`x-sort:item="1"`:                        x.SortItem("1"),
+		`x-sort:handle`:                          x.SortHandle(),
+		`x-sort:group="todos"`:                   x.SortGroup("todos"),
+		`x-sort:ignore`:                          x.SortIgnore(),
+	}
+
+	for expected, n := range cases {
+		t.Run(fmt.Sprintf("should output %v", expected), func(t *testing.T) {
+			assert.Equal(t, fmt.Sprintf(`<div %v></div>`, expected), g.El("div", n))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package x
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	g "github.com/alarbada/gomponents"
+)
+
+// Definition of a global Alpine store or component, registered with Setup.
+type Definition struct {
+	register string
+	name     string
+	value    any
+	methods  []DataMethod
+}
+
+// Store defines a global store with Alpine.store, with v encoded as the initial state and the given methods.
+// v must encode to a JSON object, so it's usually a struct or a map.
+func Store(name string, v any, methods ...DataMethod) Definition {
+	return Definition{register: "store", name: name, value: v, methods: methods}
+}
+
+// Component defines a reusable component with Alpine.data, to use by name in x-data.
+// Every component instance starts with v encoded as its state, and has the given methods.
+// v must encode to a JSON object, so it's usually a struct or a map.
+func Component(name string, v any, methods ...DataMethod) Definition {
+	return Definition{register: "data", name: name, value: v, methods: methods}
+}
+
+// Setup renders an inline script element, which registers the definitions when Alpine initializes.
+// It must come before the Alpine script in the page, so it runs before Alpine boots.
+// Method bodies are rendered as is, so they must not contain "</script>".
+// Encoding errors are returned when rendering.
+func Setup(definitions ...Definition) g.Node {
+	return g.El("script", g.NodeFunc(func(w io.Writer) error {
+		var b strings.Builder
+		b.WriteString(`document.addEventListener("alpine:init", () => {`)
+		for _, d := range definitions {
+			object, err := objectLiteral(d.value, d.methods)
+			if err != nil {
+				return err
+			}
+			name, err := json.Marshal(d.name)
+			if err != nil {
+				return err
+			}
+
+			b.WriteString("\nAlpine.")
+			b.WriteString(d.register)
+			b.WriteString("(")
+			b.Write(name)
+			if d.register == "data" {
+				b.WriteString(", () => (")
+				b.WriteString(object)
+				b.WriteString("));")
+			} else {
+				b.WriteString(", ")
+				b.WriteString(object)
+				b.WriteString(");")
+			}
+		}
+		b.WriteString("\n});")
+
+		_, err := w.Write([]byte(b.String()))
+		return err
+	}))
+}
@@ -0,0 +1,27 @@
+package x_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alarbada/gomponents/internal/assert"
+	"github.com/alarbada/gomponents/x"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("registers stores and components on alpine:init", func(t *testing.T) {
+		n := x.Setup(
+			x.Store("cart", map[string]int{"count": 0}, x.Method("add", "this.count++")),
+			x.Component("dropdown", dropdown{Label: "</script>"}, x.Method("toggle", "this.open = !this.open")),
+		)
+		assert.Equal(t, `<script>document.addEventListener("alpine:init", () => {
+Alpine.store("cart", {"count":0, add() { this.count++ }});
+Alpine.data("dropdown", () => ({"open":false,"label":"\u003c/script\u003e", toggle() { this.open = !this.open }}));
+});</script>`, n)
+	})
+
+	t.Run("returns an error when rendering something that isn't an object", func(t *testing.T) {
+		err := x.Setup(x.Store("count", 1)).Render(&strings.Builder{})
+		assert.Error(t, err)
+	})
+}
@@ -1,9 +1,105 @@
+// Package x provides attributes and helpers for Alpine.js directives.
+// See https://alpinejs.dev/directives
+//
+// Unlike htmx in package hx, Alpine itself is not embedded yet, so pages must load it from a CDN or
+// from a copy served by the application.
 package x
 
 import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
 	g "github.com/alarbada/gomponents"
 )
 
-func Data(value string) g.Node { return g.Attr("x-data", value) }
-func On(value string) g.Node   { return g.Attr("x-on", value) }
-func Init(value string) g.Node { return g.Attr("x-init", value) }
+func Cloak() g.Node                        { return g.Attr("x-cloak") }
+func Data(value string) g.Node             { return g.Attr("x-data", value) }
+func Effect(expr string) g.Node            { return g.Attr("x-effect", expr) }
+func HTML(expr string) g.Node              { return g.Attr("x-html", expr) }
+func Ignore() g.Node                       { return g.Attr("x-ignore") }
+func Init(value string) g.Node             { return g.Attr("x-init", value) }
+func Modelable(expr string) g.Node         { return g.Attr("x-modelable", expr) }
+func Ref(name string) g.Node               { return g.Attr("x-ref", name) }
+func Show(expr string) g.Node              { return g.Attr("x-show", expr) }
+func Text(expr string) g.Node              { return g.Attr("x-text", expr) }
+func TransitionEnter(v string) g.Node      { return g.Attr("x-transition:enter", v) }
+func TransitionEnterEnd(v string) g.Node   { return g.Attr("x-transition:enter-end", v) }
+func TransitionEnterStart(v string) g.Node { return g.Attr("x-transition:enter-start", v) }
+func TransitionLeave(v string) g.Node      { return g.Attr("x-transition:leave", v) }
+func TransitionLeaveEnd(v string) g.Node   { return g.Attr("x-transition:leave-end", v) }
+func TransitionLeaveStart(v string) g.Node { return g.Attr("x-transition:leave-start", v) }
+
+// On listens for the event and runs handler, like x-on:click.prevent="open = true".
+// Modifiers are added to the attribute name, like "prevent", "outside", or Debounce(250*time.Millisecond).
+func On(event, handler string, modifiers ...string) g.Node {
+	return g.Attr("x-on:"+event+joinModifiers(modifiers), handler)
+}
+
+// Bind sets the attribute to the result of the expression, like x-bind:disabled="!valid".
+func Bind(attr, expr string) g.Node {
+	return g.Attr("x-bind:"+attr, expr)
+}
+
+// Model binds the value of an input element to the data property expr.
+// Modifiers are added to the attribute name, like "lazy", "number", or Debounce(500*time.Millisecond).
+func Model(expr string, modifiers ...string) g.Node {
+	return g.Attr("x-model"+joinModifiers(modifiers), expr)
+}
+
+// Transition shows and hides the element with the default transition, or the one changed by modifiers,
+// like "opacity", "scale.90", or "duration.500ms".
+func Transition(modifiers ...string) g.Node {
+	return g.Attr("x-transition" + joinModifiers(modifiers))
+}
+
+// ID scopes the given names for $id() to the element and its children.
+func ID(names ...string) g.Node {
+	b, _ := json.Marshal(names)
+	return g.Attr("x-id", string(b))
+}
+
+// If renders a template element, which adds its content to the page only when the expression is true.
+// The content must be a single element.
+func If(expr string, child g.Node) g.Node {
+	return g.El("template", g.Attr("x-if", expr), child)
+}
+
+// For renders a template element, which repeats its content for each item in expr, like "todo in todos".
+// The content must be a single element.
+func For(expr string, child g.Node) g.Node {
+	return g.El("template", g.Attr("x-for", expr), child)
+}
+
+// ForKey is like For, but with a key expression to identify each item, like "todo.id".
+func ForKey(expr, key string, child g.Node) g.Node {
+	return g.El("template", g.Attr("x-for", expr), Bind("key", key), child)
+}
+
+// Teleport renders a template element, which moves its content to the element matching selector.
+// The content must be a single element.
+func Teleport(selector string, child g.Node) g.Node {
+	return g.El("template", g.Attr("x-teleport", selector), child)
+}
+
+// Debounce modifier for On and Model, which waits until d has passed without another event.
+func Debounce(d time.Duration) string {
+	return "debounce." + formatDuration(d)
+}
+
+// Throttle modifier for On, which handles the event at most once every d.
+func Throttle(d time.Duration) string {
+	return "throttle." + formatDuration(d)
+}
+
+func joinModifiers(modifiers []string) string {
+	if len(modifiers) == 0 {
+		return ""
+	}
+	return "." + strings.Join(modifiers, ".")
+}
+
+func formatDuration(d time.Duration) string {
+	return strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+}
@@ -0,0 +1,119 @@
+package x_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	g "github.com/alarbada/gomponents"
+	"github.com/alarbada/gomponents/internal/assert"
+	"github.com/alarbada/gomponents/x"
+)
+
+func TestBooleanAttributes(t *testing.T) {
+	cases := map[string]func() g.Node{
+		"x-cloak":  x.Cloak,
+		"x-ignore": x.Ignore,
+	}
+
+	for name, fn := range cases {
+		t.Run(fmt.Sprintf("should output %v", name), func(t *testing.T) {
+			n := g.El("div", fn())
+			assert.Equal(t, fmt.Sprintf(`<div %v></div>`, name), n)
+		})
+	}
+}
+
+func TestSimpleAttributes(t *testing.T) {
+	cases := map[string]func(string) g.Node{
+		"x-data":                   x.Data,
+		"x-effect":                 x.Effect,
+		"x-html":                   x.HTML,
+		"x-init":                   x.Init,
+		"x-modelable":              x.Modelable,
+		"x-ref":                    x.Ref,
+		"x-show":                   x.Show,
+		"x-text":                   x.Text,
+		"x-transition:enter":       x.TransitionEnter,
+		"x-transition:enter-end":   x.TransitionEnterEnd,
+		"x-transition:enter-start": x.TransitionEnterStart,
+		"x-transition:leave":       x.TransitionLeave,
+		"x-transition:leave-end":   x.TransitionLeaveEnd,
+		"x-transition:leave-start": x.TransitionLeaveStart,
+	}
+
+	for name, fn := range cases {
+		t.Run(fmt.Sprintf(`should output %v="hat"`, name), func(t *testing.T) {
+			n := g.El("div", fn("hat"))
+			assert.Equal(t, fmt.Sprintf(`<div %v="hat"></div>`, name), n)
+		})
+	}
+}
+
+func TestOn(t *testing.T) {
+	t.Run("returns an attribute for the event", func(t *testing.T) {
+		assert.Equal(t, ` x-on:click="open = true"`, x.On("click", "open = true"))
+	})
+
+	t.Run("adds modifiers to the attribute name", func(t *testing.T) {
+		n := x.On("keyup", "search()", "enter", x.Debounce(250*time.Millisecond))
+		assert.Equal(t, ` x-on:keyup.enter.debounce.250ms="search()"`, n)
+	})
+
+	t.Run("adds throttle modifier", func(t *testing.T) {
+		n := x.On("scroll", "update()", "window", x.Throttle(time.Second))
+		assert.Equal(t, ` x-on:scroll.window.throttle.1000ms="update()"`, n)
+	})
+}
+
+func TestBind(t *testing.T) {
+	t.Run("returns an attribute for the bound attribute", func(t *testing.T) {
+		assert.Equal(t, ` x-bind:disabled="!valid"`, x.Bind("disabled", "!valid"))
+	})
+}
+
+func TestModel(t *testing.T) {
+	t.Run("returns an attribute without modifiers", func(t *testing.T) {
+		assert.Equal(t, ` x-model="name"`, x.Model("name"))
+	})
+
+	t.Run("adds modifiers to the attribute name", func(t *testing.T) {
+		assert.Equal(t, ` x-model.lazy.number="age"`, x.Model("age", "lazy", "number"))
+		assert.Equal(t, ` x-model.debounce.500ms="search"`, x.Model("search", x.Debounce(500*time.Millisecond)))
+	})
+}
+
+func TestTransition(t *testing.T) {
+	t.Run("returns a boolean attribute without modifiers", func(t *testing.T) {
+		assert.Equal(t, ` x-transition`, x.Transition())
+	})
+
+	t.Run("adds modifiers to the attribute name", func(t *testing.T) {
+		assert.Equal(t, ` x-transition.opacity.duration.500ms`, x.Transition("opacity", "duration.500ms"))
+	})
+}
+
+func TestID(t *testing.T) {
+	t.Run("returns an array of the names", func(t *testing.T) {
+		assert.Equal(t, ` x-id="[&#34;list-item&#34;,&#34;tab&#34;]"`, x.ID("list-item", "tab"))
+	})
+}
+
+func TestTemplates(t *testing.T) {
+	t.Run("renders x-if in a template", func(t *testing.T) {
+		assert.Equal(t, `<template x-if="open"><div></div></template>`, x.If("open", g.El("div")))
+	})
+
+	t.Run("renders x-for in a template", func(t *testing.T) {
+		assert.Equal(t, `<template x-for="todo in todos"><li></li></template>`, x.For("todo in todos", g.El("li")))
+	})
+
+	t.Run("renders keyed x-for in a template", func(t *testing.T) {
+		assert.Equal(t, `<template x-for="todo in todos" x-bind:key="todo.id"><li></li></template>`,
+			x.ForKey("todo in todos", "todo.id", g.El("li")))
+	})
+
+	t.Run("renders x-teleport in a template", func(t *testing.T) {
+		assert.Equal(t, `<template x-teleport="body"><div></div></template>`, x.Teleport("body", g.El("div")))
+	})
+}